@@ -3,6 +3,7 @@ import type {
   CreateBoardResponse,
   GetBoardResponse,
   GetBoardsByUserIDResponse,
+  JoinBoardSessionResponse,
   UpdateBoardRequest,
 } from "./types";
 
@@ -20,9 +21,9 @@ export const getBoard = async (id: string) => {
   return data;
 };
 
-export const getBoards = async () => {
+export const getBoards = async (cursor?: string) => {
   const { data, error, status } =
-    await apiClient.get<GetBoardsByUserIDResponse>("/boards");
+    await apiClient.get<GetBoardsByUserIDResponse>("/boards", { cursor });
   if (error) {
     handleApiError(error, status);
   }
@@ -50,3 +51,19 @@ export const updateBoard = async (id: string, req: UpdateBoardRequest) => {
   }
   return data;
 };
+
+export const joinBoardSession = async (id: string) => {
+  const { data, error, status } =
+    await apiClient.post<JoinBoardSessionResponse>(`/boards/${id}/session`, {});
+  if (error) {
+    handleApiError(error, status);
+  }
+  return data;
+};
+
+export const leaveBoardSession = async (id: string) => {
+  const { error, status } = await apiClient.delete(`/boards/${id}/session`);
+  if (error) {
+    handleApiError(error, status);
+  }
+};
@@ -0,0 +1,155 @@
+import { useCallback, useEffect, useRef } from "react";
+import type { Room } from "livekit-client";
+import { CaptureUpdateAction } from "@excalidraw/excalidraw";
+import type {
+  ExcalidrawElement,
+  OrderedExcalidrawElement,
+} from "@excalidraw/excalidraw/element/types";
+import type { ExcalidrawImperativeAPI } from "@excalidraw/excalidraw/types";
+import { useDebouncedCallback } from "@/lib/use-debounced-callback";
+
+// BOARD_TOPIC carries live element changes. Participants send theirs to the
+// bot only; the bot merges them into its copy of the board and broadcasts
+// the ones that won.
+export const BOARD_TOPIC = "board";
+
+// BOT_IDENTITY is the bot's participant identity. Board changes are applied
+// only from the bot: every member may publish data so that viewers can chat,
+// so a change sent straight from a peer has not been checked.
+export const BOT_IDENTITY = "bot";
+
+const ELEMENTS_TYPE = "elements";
+
+// How long edits are batched before they are sent.
+const SEND_DELAY = 100;
+
+interface ElementsMessage {
+  type: string;
+  data?: {
+    elements?: ExcalidrawElement[];
+    from?: string;
+  };
+}
+
+// supersedes mirrors the server's rule: the higher version wins and, on equal
+// versions, the lower versionNonce.
+const supersedes = (
+  incoming: ExcalidrawElement,
+  current: ExcalidrawElement
+) => {
+  if (incoming.version !== current.version) {
+    return incoming.version > current.version;
+  }
+  return incoming.versionNonce < current.versionNonce;
+};
+
+// useBoardSync keeps the scene in step with the board's live session. It
+// returns the callbacks to hand to the whiteboard: one for its API, and one
+// for its element changes.
+export const useBoardSync = (
+  room: Room,
+  initialElements: readonly ExcalidrawElement[]
+) => {
+  const apiRef = useRef<ExcalidrawImperativeAPI | null>(null);
+  // The version of each element as the bot last saw it, so that only local
+  // changes are sent and changes received are not echoed back.
+  const knownVersions = useRef<Map<string, number> | null>(null);
+  if (knownVersions.current === null) {
+    knownVersions.current = new Map(
+      initialElements.map((element) => [element.id, element.version])
+    );
+  }
+
+  useEffect(() => {
+    room.registerTextStreamHandler(
+      BOARD_TOPIC,
+      async (reader, participantInfo) => {
+        const message = await reader.readAll();
+        if (participantInfo.identity !== BOT_IDENTITY) {
+          return;
+        }
+        let parsed: ElementsMessage;
+        try {
+          parsed = JSON.parse(message);
+        } catch (error) {
+          console.warn("Malformed board message:", error);
+          return;
+        }
+        const api = apiRef.current;
+        if (parsed.type !== ELEMENTS_TYPE || !api) {
+          return;
+        }
+
+        const scene = new Map<string, ExcalidrawElement>(
+          api
+            .getSceneElementsIncludingDeleted()
+            .map((element) => [element.id, element])
+        );
+        let changed = false;
+        for (const element of parsed.data?.elements ?? []) {
+          const current = scene.get(element.id);
+          if (current && !supersedes(element, current)) {
+            continue;
+          }
+          scene.set(element.id, element);
+          knownVersions.current?.set(element.id, element.version);
+          changed = true;
+        }
+        if (changed) {
+          api.updateScene({
+            elements: Array.from(scene.values()) as OrderedExcalidrawElement[],
+            captureUpdate: CaptureUpdateAction.NEVER,
+          });
+        }
+      }
+    );
+
+    return () => {
+      room.unregisterTextStreamHandler(BOARD_TOPIC);
+    };
+  }, [room]);
+
+  const sendChanges = useDebouncedCallback(
+    (elements: readonly ExcalidrawElement[]) => {
+      // Viewers' changes would be dropped by the bot anyway.
+      if (!room.localParticipant.permissions?.canPublish) {
+        return;
+      }
+      const known = knownVersions.current;
+      const changed = elements.filter(
+        (element) => known?.get(element.id) !== element.version
+      );
+      if (changed.length === 0) {
+        return;
+      }
+      for (const element of changed) {
+        known?.set(element.id, element.version);
+      }
+      room.localParticipant
+        .sendText(
+          JSON.stringify({
+            type: ELEMENTS_TYPE,
+            data: { elements: changed },
+          }),
+          {
+            topic: BOARD_TOPIC,
+            destinationIdentities: [BOT_IDENTITY],
+          }
+        )
+        .catch((error) => {
+          console.error("Failed to send board changes:", error);
+          // Sent again with the next change.
+          for (const element of changed) {
+            known?.delete(element.id);
+          }
+        });
+    },
+    SEND_DELAY
+  );
+
+  const handleAPI = useCallback((api: ExcalidrawImperativeAPI) => {
+    apiRef.current = api;
+  }, []);
+
+  return { handleAPI, sendChanges };
+};
@@ -2,9 +2,15 @@ import {
   useMutation,
   useQueryClient,
   useQuery,
-  keepPreviousData,
+  useInfiniteQuery,
 } from "@tanstack/react-query";
-import { createBoard, getBoard, getBoards, updateBoard } from "../api";
+import {
+  createBoard,
+  getBoard,
+  getBoards,
+  joinBoardSession,
+  updateBoard,
+} from "../api";
 import type { UpdateBoardRequest } from "../types";
 
 // Query hooks
@@ -15,11 +21,26 @@ export const useQueryGetBoard = (id: string) => {
   });
 };
 
-export const useQueryGetBoards = () => {
+// Joining starts the board's bot, so the token is fetched once per visit
+// rather than refreshed in the background.
+export const useQueryJoinBoardSession = (id: string) => {
   return useQuery({
+    queryKey: ["board-session", id],
+    queryFn: () => joinBoardSession(id),
+    staleTime: Infinity,
+    gcTime: 0,
+    refetchOnWindowFocus: false,
+  });
+};
+
+// The server lists boards a page at a time; each page names the cursor of
+// the next, and the last has none.
+export const useQueryGetBoards = () => {
+  return useInfiniteQuery({
     queryKey: ["boards"],
-    queryFn: () => getBoards(),
-    placeholderData: keepPreviousData,
+    queryFn: ({ pageParam }) => getBoards(pageParam),
+    initialPageParam: undefined as string | undefined,
+    getNextPageParam: (lastPage) => lastPage?.nextCursor || undefined,
   });
 };
 
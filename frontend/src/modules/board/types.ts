@@ -11,11 +11,44 @@ export interface Board {
 
 export interface GetBoardResponse {
   board: Board;
+}
+
+export interface SessionParticipant {
+  identity: string;
+  name: string;
+  muted: boolean;
+  canPublish: boolean;
+}
+
+export interface BoardSession {
+  active: boolean;
+  botState: string;
+  participants: SessionParticipant[];
+  voiceReplies: boolean;
+}
+
+export interface JoinBoardSessionResponse {
   token: string;
+  session: BoardSession;
+}
+
+export interface BoardSummary {
+  id: string;
+  name: string;
+  ownerId: string;
+  role: string;
+  version: number;
+  elementCount: number;
+  folderId?: string;
+  tags: string[];
+  thumbnailUrl?: string;
+  createdAt: string;
+  updatedAt: string;
 }
 
 export interface GetBoardsByUserIDResponse {
-  boards: Board[];
+  boards: BoardSummary[];
+  nextCursor?: string;
 }
 
 export interface UpdateBoardRequest {
@@ -24,6 +24,7 @@ export interface SentimentData {
 // Transcript message
 export interface TranscriptData {
   role: string; // "user" or "ai"
+  identity: string; // Speaker's participant identity
   name: string; // Speaker's name
   content: string; // Transcript text
   timestamp: string; // When the segment was captured
@@ -302,7 +303,7 @@ export const DraggableControlsLayout = ({
               if (
                 lastTranscript &&
                 lastTranscript.role === transcriptData.role &&
-                lastTranscript.name === transcriptData.name &&
+                lastTranscript.identity === transcriptData.identity &&
                 lastTranscript.timestamp === transcriptData.timestamp
               ) {
                 // Same speaker, same turn - update the content in place
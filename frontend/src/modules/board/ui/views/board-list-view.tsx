@@ -3,11 +3,24 @@ import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
 import { useMutationCreateBoard } from "../../hooks/use-board";
 import { useNavigate } from "@tanstack/react-router";
 import { generateSlug } from "random-word-slugs";
-import type { Board } from "../../types";
+import type { BoardSummary } from "../../types";
 import { PlusIcon, FileTextIcon, Loader2Icon } from "lucide-react";
 import { cn } from "@/lib/utils";
 
-export const BoardListView = ({ boards }: { boards: Board[] }) => {
+interface BoardListViewProps {
+  boards: BoardSummary[];
+  // hasMore is set while the server has boards past the loaded pages.
+  hasMore?: boolean;
+  loadingMore?: boolean;
+  onLoadMore?: () => void;
+}
+
+export const BoardListView = ({
+  boards,
+  hasMore = false,
+  loadingMore = false,
+  onLoadMore,
+}: BoardListViewProps) => {
   const createBoard = useMutationCreateBoard();
   const navigate = useNavigate();
 
@@ -45,7 +58,7 @@ export const BoardListView = ({ boards }: { boards: Board[] }) => {
           <p className="text-sm text-muted-foreground mt-1">
             {boards.length === 0
               ? "No boards yet"
-              : `${boards.length} ${boards.length === 1 ? "board" : "boards"}`}
+              : `${boards.length}${hasMore ? "+" : ""} ${boards.length === 1 && !hasMore ? "board" : "boards"}`}
           </p>
         </div>
         <Button
@@ -115,6 +128,19 @@ export const BoardListView = ({ boards }: { boards: Board[] }) => {
                 </Card>
               ))}
             </div>
+            {hasMore && (
+              <div className="flex justify-center mt-6">
+                <Button
+                  variant="outline"
+                  onClick={onLoadMore}
+                  disabled={loadingMore}
+                  className="gap-2"
+                >
+                  {loadingMore && <Loader2Icon className="size-4 animate-spin" />}
+                  Load more
+                </Button>
+              </div>
+            )}
           </div>
         )}
       </div>
@@ -2,15 +2,12 @@ import { useNavigate } from "@tanstack/react-router";
 import { useEffect } from "react";
 import { RoomEvent } from "livekit-client";
 import "@livekit/components-styles";
-import {
-  Whiteboard,
-  type WhiteboardStateChange,
-  convertFromExcalidrawElements,
-} from "./whiteboard";
+import type { ExcalidrawElement } from "@excalidraw/excalidraw/element/types";
+import { Whiteboard, type WhiteboardStateChange } from "./whiteboard";
 import type { Board } from "../../types";
 import { DraggableControlsLayout } from "../components/draggable-controls-layout";
 import { LiveKitRoom, useRoomContext } from "@livekit/components-react";
-import { useMutationUpdateBoard } from "../../hooks/use-board";
+import { useBoardSync } from "../../hooks/use-board-sync";
 
 const SERVER_URL = "wss://conversense-z0ptqzuw.livekit.cloud";
 
@@ -46,9 +43,31 @@ const MuteOnJoin = () => {
   return null;
 };
 
+// LiveWhiteboard edits the board through the room's live session, which
+// merges everyone's changes and saves them.
+const LiveWhiteboard = ({ board }: { board: Board }) => {
+  const room = useRoomContext();
+  const { handleAPI, sendChanges } = useBoardSync(
+    room,
+    (board.elements ?? []) as unknown as ExcalidrawElement[]
+  );
+
+  const handleStateChange = (state: WhiteboardStateChange) => {
+    sendChanges(state.elements);
+  };
+
+  return (
+    <Whiteboard
+      board={board}
+      onStateChange={handleStateChange}
+      changeDelay={0}
+      onApiReady={handleAPI}
+    />
+  );
+};
+
 export const BoardRoomView = ({ board, token }: BoardRoomViewProps) => {
   const navigate = useNavigate();
-  const { mutate: updateBoardMutation } = useMutationUpdateBoard();
 
   if (!token) {
     return (
@@ -58,16 +77,6 @@ export const BoardRoomView = ({ board, token }: BoardRoomViewProps) => {
     );
   }
 
-  const handleStateChange = (state: WhiteboardStateChange) => {
-    const commands = convertFromExcalidrawElements(state.elements);
-    updateBoardMutation({
-      id: board.id.toString(),
-      req: {
-        elements: commands,
-      },
-    });
-  };
-
   return (
     <div className="h-screen w-screen relative bg-white overflow-hidden">
       <LiveKitRoom
@@ -90,7 +99,7 @@ export const BoardRoomView = ({ board, token }: BoardRoomViewProps) => {
             className="absolute inset-0"
             style={{ width: "100%", height: "100%" }}
           >
-            <Whiteboard board={board} onStateChange={handleStateChange} />
+            <LiveWhiteboard board={board} />
           </div>
           <DraggableControlsLayout />
         </div>
@@ -1,11 +1,10 @@
 import React, { useState, useRef, useCallback } from "react";
 import { Excalidraw } from "@excalidraw/excalidraw";
 import "@excalidraw/excalidraw/index.css";
-import { convertToExcalidrawElements } from "@excalidraw/excalidraw";
+import { restoreElements } from "@excalidraw/excalidraw";
 import { useDebouncedCallback } from "@/lib/use-debounced-callback";
 import BoardHeader from "./board-header";
 import type { Board } from "../../types";
-import type { ExcalidrawElementSkeleton } from "@excalidraw/excalidraw/data/transform";
 import type { OrderedExcalidrawElement } from "@excalidraw/excalidraw/element/types";
 
 export interface WhiteboardStateChange {
@@ -17,6 +16,9 @@ export interface WhiteboardStateChange {
 export interface WhiteboardProps {
   board: Board;
   onStateChange?: (state: WhiteboardStateChange) => void;
+  // How long changes are batched before onStateChange, in milliseconds.
+  changeDelay?: number;
+  onApiReady?: (api: ExcalidrawAPI) => void;
 }
 
 type ExcalidrawOnChange = Parameters<
@@ -119,13 +121,18 @@ export function convertFromExcalidrawElements(
   });
 }
 
-export const Whiteboard = ({ board, onStateChange }: WhiteboardProps) => {
-  const boardElements = (board.elements ?? []) as ExcalidrawElementSkeleton[];
-
-  const initialElements: OrderedExcalidrawElement[] =
-    convertToExcalidrawElements(boardElements, {
-      regenerateIds: false,
-    });
+export const Whiteboard = ({
+  board,
+  onStateChange,
+  changeDelay,
+  onApiReady,
+}: WhiteboardProps) => {
+  // Stored elements are whole Excalidraw elements. Restoring keeps their
+  // versions, which live edits are merged by.
+  const initialElements: OrderedExcalidrawElement[] = restoreElements(
+    (board.elements ?? []) as unknown as OrderedExcalidrawElement[],
+    null
+  );
 
   const excalidrawAPI = useRef<ExcalidrawAPI | null>(null);
   const previousElementsRef =
@@ -193,7 +200,8 @@ export const Whiteboard = ({ board, onStateChange }: WhiteboardProps) => {
           appState,
         });
       }
-    }
+    },
+    changeDelay
   );
 
   const handleChange = useCallback(
@@ -212,9 +220,13 @@ export const Whiteboard = ({ board, onStateChange }: WhiteboardProps) => {
     [notifyStateChange, elementsHaveChanged]
   );
 
-  const handleAPI = useCallback((api: ExcalidrawAPI) => {
-    excalidrawAPI.current = api;
-  }, []);
+  const handleAPI = useCallback(
+    (api: ExcalidrawAPI) => {
+      excalidrawAPI.current = api;
+      onApiReady?.(api);
+    },
+    [onApiReady]
+  );
 
   return (
     <div className="h-full w-full relative flex flex-col">
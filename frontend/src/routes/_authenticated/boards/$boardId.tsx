@@ -1,8 +1,16 @@
 import { QueryBoundary } from "@/components/query-boundary";
-import { useQueryGetBoard } from "@/modules/board/hooks/use-board";
-import type { GetBoardResponse } from "@/modules/board/types";
+import { leaveBoardSession } from "@/modules/board/api";
+import {
+  useQueryGetBoard,
+  useQueryJoinBoardSession,
+} from "@/modules/board/hooks/use-board";
+import type {
+  GetBoardResponse,
+  JoinBoardSessionResponse,
+} from "@/modules/board/types";
 import { BoardRoomView } from "@/modules/board/ui/views/board-room-view";
 import { createFileRoute } from "@tanstack/react-router";
+import { useEffect } from "react";
 
 export const Route = createFileRoute("/_authenticated/boards/$boardId")({
   component: RouteComponent,
@@ -11,14 +19,23 @@ export const Route = createFileRoute("/_authenticated/boards/$boardId")({
 function RouteComponent() {
   const { boardId } = Route.useParams();
   const boardQuery = useQueryGetBoard(boardId);
+  const sessionQuery = useQueryJoinBoardSession(boardId);
+
+  useEffect(() => {
+    return () => {
+      leaveBoardSession(boardId).catch(() => {});
+    };
+  }, [boardId]);
 
   return (
     <>
       <QueryBoundary query={boardQuery}>
         {(data: GetBoardResponse) => (
-          <>
-            <BoardRoomView board={data.board} token={data.token} />
-          </>
+          <QueryBoundary query={sessionQuery}>
+            {(session: JoinBoardSessionResponse) => (
+              <BoardRoomView board={data.board} token={session.token} />
+            )}
+          </QueryBoundary>
         )}
       </QueryBoundary>
     </>
@@ -1,6 +1,8 @@
-import { QueryBoundary } from "@/components/query-boundary";
+import {
+  DefaultErrorFallback,
+  DefaultLoadingFallback,
+} from "@/components/query-boundary";
 import { useQueryGetBoards } from "@/modules/board/hooks/use-board";
-import type { GetBoardsByUserIDResponse } from "@/modules/board/types";
 import { BoardListView } from "@/modules/board/ui/views/board-list-view";
 import { createFileRoute } from "@tanstack/react-router";
 
@@ -11,13 +13,20 @@ export const Route = createFileRoute("/_authenticated/boards/")({
 function RouteComponent() {
   const getBoards = useQueryGetBoards();
 
+  if (getBoards.isLoading) {
+    return <DefaultLoadingFallback />;
+  }
+  if (getBoards.isError) {
+    return <DefaultErrorFallback />;
+  }
+
+  const boards = getBoards.data?.pages.flatMap((page) => page?.boards ?? []);
   return (
-    <QueryBoundary query={getBoards}>
-      {(data: GetBoardsByUserIDResponse) => (
-        <>
-          <BoardListView boards={data?.boards ?? []} />
-        </>
-      )}
-    </QueryBoundary>
+    <BoardListView
+      boards={boards ?? []}
+      hasMore={getBoards.hasNextPage}
+      loadingMore={getBoards.isFetchingNextPage}
+      onLoadMore={() => getBoards.fetchNextPage()}
+    />
   );
 }
@@ -12,7 +12,9 @@ import (
 	"draw/pkg/config"
 	"draw/pkg/database"
 	"draw/pkg/inngest"
+	"draw/pkg/livekit"
 	"draw/pkg/logger"
+	"draw/pkg/storage"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -23,7 +25,9 @@ type App struct {
 	DB      database.DB
 	Service *service.Service
 	Inngest *inngest.Inngest
-	Log     *logger.Logger
+	// Sessions holds the live voice and sync session of each open board.
+	Sessions *livekit.Manager
+	Log      *logger.Logger
 }
 
 func NewApp(ctx context.Context, cfg *config.AppConfig) (*App, error) {
@@ -44,7 +48,12 @@ func NewApp(ctx context.Context, cfg *config.AppConfig) (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	services := service.NewService(dbInstance, queries, inngest, cfg)
+	store, err := storage.New(&cfg.Storage, &cfg.AWS)
+	if err != nil {
+		return nil, err
+	}
+	sessions := livekit.NewManager(cfg.LiveKit.SessionIdleTimeout)
+	services := service.NewService(dbInstance, queries, inngest, store, sessions, cfg)
 
 	traceIDFn := func(ctx context.Context) string {
 		return uuid.New().String()
@@ -60,10 +69,11 @@ func NewApp(ctx context.Context, cfg *config.AppConfig) (*App, error) {
 
 
 	return &App{
-		Config:  cfg,
-		DB:      db,
-		Service: services,
-		Inngest: inngest,
-		Log:     log,
+		Config:   cfg,
+		DB:       db,
+		Service:  services,
+		Inngest:  inngest,
+		Sessions: sessions,
+		Log:      log,
 	}, nil
 }
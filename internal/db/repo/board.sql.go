@@ -8,21 +8,34 @@ package repo
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const countBoardsByOwnerID = `-- name: CountBoardsByOwnerID :one
+SELECT count(*)::int FROM "board" WHERE owner_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountBoardsByOwnerID(ctx context.Context, ownerID string) (int32, error) {
+	row := q.db.QueryRow(ctx, countBoardsByOwnerID, ownerID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const createBoard = `-- name: CreateBoard :one
-INSERT INTO "board" (name, owner_id) VALUES ($1, $2) RETURNING id, name, owner_id, elements, created_at, updated_at
+INSERT INTO "board" (name, owner_id, elements) VALUES ($1, $2, $3) RETURNING id, name, owner_id, elements, created_at, updated_at, version, deleted_at, search_vector
 `
 
 type CreateBoardParams struct {
-	Name    string `db:"name" json:"name"`
-	OwnerID string `db:"owner_id" json:"ownerId"`
+	Name     string          `db:"name" json:"name"`
+	OwnerID  string          `db:"owner_id" json:"ownerId"`
+	Elements json.RawMessage `db:"elements" json:"elements"`
 }
 
 func (q *Queries) CreateBoard(ctx context.Context, arg CreateBoardParams) (Board, error) {
-	row := q.db.QueryRow(ctx, createBoard, arg.Name, arg.OwnerID)
+	row := q.db.QueryRow(ctx, createBoard, arg.Name, arg.OwnerID, arg.Elements)
 	var i Board
 	err := row.Scan(
 		&i.ID,
@@ -31,35 +44,222 @@ func (q *Queries) CreateBoard(ctx context.Context, arg CreateBoardParams) (Board
 		&i.Elements,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
-const deleteBoard = `-- name: DeleteBoard :exec
-DELETE FROM "board" WHERE id = $1 AND owner_id = $2
+const getBoardByID = `-- name: GetBoardByID :one
+SELECT id, name, owner_id, elements, created_at, updated_at, version, deleted_at, search_vector FROM "board" WHERE id = $1 AND deleted_at IS NULL
 `
 
-type DeleteBoardParams struct {
-	ID      uuid.UUID `db:"id" json:"id"`
-	OwnerID string    `db:"owner_id" json:"ownerId"`
+func (q *Queries) GetBoardByID(ctx context.Context, id uuid.UUID) (Board, error) {
+	row := q.db.QueryRow(ctx, getBoardByID, id)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.OwnerID,
+		&i.Elements,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.SearchVector,
+	)
+	return i, err
 }
 
-func (q *Queries) DeleteBoard(ctx context.Context, arg DeleteBoardParams) error {
-	_, err := q.db.Exec(ctx, deleteBoard, arg.ID, arg.OwnerID)
-	return err
+const getBoardByIDForUpdate = `-- name: GetBoardByIDForUpdate :one
+SELECT id, name, owner_id, elements, created_at, updated_at, version, deleted_at, search_vector FROM "board" WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+`
+
+func (q *Queries) GetBoardByIDForUpdate(ctx context.Context, id uuid.UUID) (Board, error) {
+	row := q.db.QueryRow(ctx, getBoardByIDForUpdate, id)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.OwnerID,
+		&i.Elements,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.SearchVector,
+	)
+	return i, err
 }
 
-const getBoardByID = `-- name: GetBoardByID :one
-SELECT id, name, owner_id, elements, created_at, updated_at FROM "board" WHERE id = $1 AND owner_id = $2
+const getDeletedBoardsByOwnerID = `-- name: GetDeletedBoardsByOwnerID :many
+SELECT id, name, owner_id, elements, created_at, updated_at, version, deleted_at, search_vector FROM "board" WHERE owner_id = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC
 `
 
-type GetBoardByIDParams struct {
+func (q *Queries) GetDeletedBoardsByOwnerID(ctx context.Context, ownerID string) ([]Board, error) {
+	rows, err := q.db.Query(ctx, getDeletedBoardsByOwnerID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Board{}
+	for rows.Next() {
+		var i Board
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.OwnerID,
+			&i.Elements,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBoardsByUserID = `-- name: ListBoardsByUserID :many
+SELECT
+    b.id, b.name, b.owner_id, b.created_at, b.updated_at, b.version, m.role, m.folder_id, m.tags, t.version AS thumbnail_version,
+    (
+        SELECT count(*)
+        FROM jsonb_array_elements(CASE WHEN jsonb_typeof(b.elements) = 'array' THEN b.elements ELSE '[]'::jsonb END) e
+        WHERE NOT COALESCE((e->>'isDeleted')::boolean, false)
+    )::int AS element_count
+FROM "board" b
+JOIN "board_member" m ON m.board_id = b.id
+LEFT JOIN "board_thumbnail" t ON t.board_id = b.id
+WHERE m.user_id = $1
+  AND b.deleted_at IS NULL
+  AND ($2::text IS NULL OR b.name ILIKE '%' || $2::text || '%')
+  AND (
+    $3::uuid IS NULL
+    OR ($4::text = 'name' AND $5::bool AND (b.name, b.id) < ($6::text, $3::uuid))
+    OR ($4::text = 'name' AND NOT $5::bool AND (b.name, b.id) > ($6::text, $3::uuid))
+    OR ($4::text = 'created_at' AND $5::bool AND (b.created_at, b.id) < ($7::timestamptz, $3::uuid))
+    OR ($4::text = 'created_at' AND NOT $5::bool AND (b.created_at, b.id) > ($7::timestamptz, $3::uuid))
+    OR ($4::text = 'updated_at' AND $5::bool AND (b.updated_at, b.id) < ($7::timestamptz, $3::uuid))
+    OR ($4::text = 'updated_at' AND NOT $5::bool AND (b.updated_at, b.id) > ($7::timestamptz, $3::uuid))
+  )
+  AND ($8::uuid IS NULL OR m.folder_id = $8::uuid)
+  AND ($9::text IS NULL OR $9::text = ANY(m.tags))
+ORDER BY
+    CASE WHEN $4::text = 'name' AND $5::bool THEN b.name END DESC,
+    CASE WHEN $4::text = 'name' AND NOT $5::bool THEN b.name END ASC,
+    CASE WHEN $4::text = 'created_at' AND $5::bool THEN b.created_at END DESC,
+    CASE WHEN $4::text = 'created_at' AND NOT $5::bool THEN b.created_at END ASC,
+    CASE WHEN $4::text = 'updated_at' AND $5::bool THEN b.updated_at END DESC,
+    CASE WHEN $4::text = 'updated_at' AND NOT $5::bool THEN b.updated_at END ASC,
+    CASE WHEN $5::bool THEN b.id END DESC,
+    CASE WHEN NOT $5::bool THEN b.id END ASC
+LIMIT $10
+`
+
+type ListBoardsByUserIDParams struct {
+	UserID     string     `db:"user_id" json:"userId"`
+	Search     *string    `db:"search" json:"search"`
+	CursorID   *uuid.UUID `db:"cursor_id" json:"cursorId"`
+	SortBy     string     `db:"sort_by" json:"sortBy"`
+	Descending bool       `db:"descending" json:"descending"`
+	CursorName *string    `db:"cursor_name" json:"cursorName"`
+	CursorTime *time.Time `db:"cursor_time" json:"cursorTime"`
+	FolderID   *uuid.UUID `db:"folder_id" json:"folderId"`
+	Tag        *string    `db:"tag" json:"tag"`
+	PageSize   int32      `db:"page_size" json:"pageSize"`
+}
+
+type ListBoardsByUserIDRow struct {
+	ID               uuid.UUID  `db:"id" json:"id"`
+	Name             string     `db:"name" json:"name"`
+	OwnerID          string     `db:"owner_id" json:"ownerId"`
+	CreatedAt        time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time  `db:"updated_at" json:"updatedAt"`
+	Version          int64      `db:"version" json:"version"`
+	Role             string     `db:"role" json:"role"`
+	FolderID         *uuid.UUID `db:"folder_id" json:"folderId"`
+	Tags             []string   `db:"tags" json:"tags"`
+	ThumbnailVersion *int64     `db:"thumbnail_version" json:"thumbnailVersion"`
+	ElementCount     int32      `db:"element_count" json:"elementCount"`
+}
+
+// Keyset-paginated board summaries. The cursor holds the sort key and id of
+// the last row of the previous page; only the cursor column matching sort_by
+// is read.
+func (q *Queries) ListBoardsByUserID(ctx context.Context, arg ListBoardsByUserIDParams) ([]ListBoardsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, listBoardsByUserID,
+		arg.UserID,
+		arg.Search,
+		arg.CursorID,
+		arg.SortBy,
+		arg.Descending,
+		arg.CursorName,
+		arg.CursorTime,
+		arg.FolderID,
+		arg.Tag,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBoardsByUserIDRow{}
+	for rows.Next() {
+		var i ListBoardsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.Role,
+			&i.FolderID,
+			&i.Tags,
+			&i.ThumbnailVersion,
+			&i.ElementCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedBoards = `-- name: PurgeDeletedBoards :execrows
+DELETE FROM "board" WHERE deleted_at IS NOT NULL AND deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeDeletedBoards(ctx context.Context, olderThan time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedBoards, olderThan)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreBoard = `-- name: RestoreBoard :one
+UPDATE "board" SET deleted_at = NULL WHERE id = $1 AND owner_id = $2 AND deleted_at IS NOT NULL RETURNING id, name, owner_id, elements, created_at, updated_at, version, deleted_at, search_vector
+`
+
+type RestoreBoardParams struct {
 	ID      uuid.UUID `db:"id" json:"id"`
 	OwnerID string    `db:"owner_id" json:"ownerId"`
 }
 
-func (q *Queries) GetBoardByID(ctx context.Context, arg GetBoardByIDParams) (Board, error) {
-	row := q.db.QueryRow(ctx, getBoardByID, arg.ID, arg.OwnerID)
+func (q *Queries) RestoreBoard(ctx context.Context, arg RestoreBoardParams) (Board, error) {
+	row := q.db.QueryRow(ctx, restoreBoard, arg.ID, arg.OwnerID)
 	var i Board
 	err := row.Scan(
 		&i.ID,
@@ -68,30 +268,84 @@ func (q *Queries) GetBoardByID(ctx context.Context, arg GetBoardByIDParams) (Boa
 		&i.Elements,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
-const getBoardsByUserID = `-- name: GetBoardsByUserID :many
-SELECT id, name, owner_id, elements, created_at, updated_at FROM "board" WHERE owner_id = $1
+const searchBoards = `-- name: SearchBoards :many
+SELECT
+    b.id, b.name, b.owner_id, b.updated_at, m.role,
+    ts_rank(b.search_vector, q.query)::real AS rank,
+    ts_headline(
+        'english',
+        COALESCE(matched.content, b.name),
+        q.query,
+        'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxFragments=3, MaxWords=20, MinWords=5'
+    ) AS snippet,
+    matched.element_ids
+FROM "board" b
+JOIN "board_member" m ON m.board_id = b.id
+CROSS JOIN websearch_to_tsquery('english', $1::text) AS q(query)
+CROSS JOIN LATERAL (
+    SELECT
+        COALESCE(array_agg(e.id ORDER BY e.ord), '{}')::text[] AS element_ids,
+        string_agg(e.content, ' … ' ORDER BY e.ord) AS content
+    FROM (
+        SELECT el->>'id' AS id, ord, concat_ws(' ', el->>'text', el#>>'{label,text}', el->>'name') AS content
+        FROM jsonb_array_elements(CASE WHEN jsonb_typeof(b.elements) = 'array' THEN b.elements ELSE '[]'::jsonb END) WITH ORDINALITY AS t(el, ord)
+        WHERE NOT COALESCE((el->>'isDeleted')::boolean, false)
+    ) e
+    WHERE to_tsvector('english', e.content) @@ q.query
+) matched
+WHERE m.user_id = $2
+  AND b.deleted_at IS NULL
+  AND b.search_vector @@ q.query
+ORDER BY rank DESC, b.updated_at DESC, b.id
+LIMIT $3
 `
 
-func (q *Queries) GetBoardsByUserID(ctx context.Context, ownerID string) ([]Board, error) {
-	rows, err := q.db.Query(ctx, getBoardsByUserID, ownerID)
+type SearchBoardsParams struct {
+	Query    string `db:"query" json:"query"`
+	UserID   string `db:"user_id" json:"userId"`
+	PageSize int32  `db:"page_size" json:"pageSize"`
+}
+
+type SearchBoardsRow struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	Name       string    `db:"name" json:"name"`
+	OwnerID    string    `db:"owner_id" json:"ownerId"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
+	Role       string    `db:"role" json:"role"`
+	Rank       float32   `db:"rank" json:"rank"`
+	Snippet    string    `db:"snippet" json:"snippet"`
+	ElementIds []string  `db:"element_ids" json:"elementIds"`
+}
+
+// Boards the user can access whose name or element text matches a
+// websearch-style query, best match first. element_ids lists the live
+// elements whose own text matches; snippet highlights the matches between
+// chr(2) and chr(3) so the caller can escape the text before marking it up.
+func (q *Queries) SearchBoards(ctx context.Context, arg SearchBoardsParams) ([]SearchBoardsRow, error) {
+	rows, err := q.db.Query(ctx, searchBoards, arg.Query, arg.UserID, arg.PageSize)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Board{}
+	items := []SearchBoardsRow{}
 	for rows.Next() {
-		var i Board
+		var i SearchBoardsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
 			&i.OwnerID,
-			&i.Elements,
-			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Role,
+			&i.Rank,
+			&i.Snippet,
+			&i.ElementIds,
 		); err != nil {
 			return nil, err
 		}
@@ -103,24 +357,30 @@ func (q *Queries) GetBoardsByUserID(ctx context.Context, ownerID string) ([]Boar
 	return items, nil
 }
 
+const softDeleteBoard = `-- name: SoftDeleteBoard :execrows
+UPDATE "board" SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteBoard(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteBoard, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateBoard = `-- name: UpdateBoard :one
-UPDATE "board" SET name = $2, elements = $3 WHERE id = $1 AND owner_id = $4 RETURNING id, name, owner_id, elements, created_at, updated_at
+UPDATE "board" SET name = $2, elements = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id, name, owner_id, elements, created_at, updated_at, version, deleted_at, search_vector
 `
 
 type UpdateBoardParams struct {
 	ID       uuid.UUID       `db:"id" json:"id"`
 	Name     string          `db:"name" json:"name"`
 	Elements json.RawMessage `db:"elements" json:"elements"`
-	OwnerID  string          `db:"owner_id" json:"ownerId"`
 }
 
 func (q *Queries) UpdateBoard(ctx context.Context, arg UpdateBoardParams) (Board, error) {
-	row := q.db.QueryRow(ctx, updateBoard,
-		arg.ID,
-		arg.Name,
-		arg.Elements,
-		arg.OwnerID,
-	)
+	row := q.db.QueryRow(ctx, updateBoard, arg.ID, arg.Name, arg.Elements)
 	var i Board
 	err := row.Scan(
 		&i.ID,
@@ -129,6 +389,9 @@ func (q *Queries) UpdateBoard(ctx context.Context, arg UpdateBoardParams) (Board
 		&i.Elements,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
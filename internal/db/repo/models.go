@@ -12,12 +12,82 @@ import (
 )
 
 type Board struct {
-	ID        uuid.UUID       `db:"id" json:"id"`
-	Name      string          `db:"name" json:"name"`
-	OwnerID   string          `db:"owner_id" json:"ownerId"`
-	Elements  json.RawMessage `db:"elements" json:"elements"`
-	CreatedAt time.Time       `db:"created_at" json:"createdAt"`
-	UpdatedAt time.Time       `db:"updated_at" json:"updatedAt"`
+	ID           uuid.UUID       `db:"id" json:"id"`
+	Name         string          `db:"name" json:"name"`
+	OwnerID      string          `db:"owner_id" json:"ownerId"`
+	Elements     json.RawMessage `db:"elements" json:"elements"`
+	CreatedAt    time.Time       `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time       `db:"updated_at" json:"updatedAt"`
+	Version      int64           `db:"version" json:"version"`
+	DeletedAt    *time.Time      `db:"deleted_at" json:"deletedAt"`
+	SearchVector interface{}     `db:"search_vector" json:"searchVector"`
+}
+
+type BoardFile struct {
+	BoardID     uuid.UUID `db:"board_id" json:"boardId"`
+	FileID      string    `db:"file_id" json:"fileId"`
+	MimeType    string    `db:"mime_type" json:"mimeType"`
+	Size        int64     `db:"size" json:"size"`
+	StorageKey  string    `db:"storage_key" json:"storageKey"`
+	CreatedBy   *string   `db:"created_by" json:"createdBy"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	ContentHash *string   `db:"content_hash" json:"contentHash"`
+}
+
+type BoardMember struct {
+	BoardID   uuid.UUID  `db:"board_id" json:"boardId"`
+	UserID    string     `db:"user_id" json:"userId"`
+	Role      string     `db:"role" json:"role"`
+	InvitedBy *string    `db:"invited_by" json:"invitedBy"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	FolderID  *uuid.UUID `db:"folder_id" json:"folderId"`
+	Tags      []string   `db:"tags" json:"tags"`
+}
+
+type BoardRevision struct {
+	ID           uuid.UUID       `db:"id" json:"id"`
+	BoardID      uuid.UUID       `db:"board_id" json:"boardId"`
+	Revision     int32           `db:"revision" json:"revision"`
+	Elements     json.RawMessage `db:"elements" json:"elements"`
+	ElementCount int32           `db:"element_count" json:"elementCount"`
+	CreatedBy    *string         `db:"created_by" json:"createdBy"`
+	CreatedAt    time.Time       `db:"created_at" json:"createdAt"`
+}
+
+type BoardShareLink struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	BoardID        uuid.UUID  `db:"board_id" json:"boardId"`
+	Token          string     `db:"token" json:"token"`
+	CreatedBy      *string    `db:"created_by" json:"createdBy"`
+	ExpiresAt      *time.Time `db:"expires_at" json:"expiresAt"`
+	AccessCount    int64      `db:"access_count" json:"accessCount"`
+	LastAccessedAt *time.Time `db:"last_accessed_at" json:"lastAccessedAt"`
+	CreatedAt      time.Time  `db:"created_at" json:"createdAt"`
+}
+
+type BoardTemplate struct {
+	ID          uuid.UUID       `db:"id" json:"id"`
+	Name        string          `db:"name" json:"name"`
+	Description string          `db:"description" json:"description"`
+	OwnerID     *string         `db:"owner_id" json:"ownerId"`
+	Elements    json.RawMessage `db:"elements" json:"elements"`
+	CreatedAt   time.Time       `db:"created_at" json:"createdAt"`
+}
+
+type BoardThumbnail struct {
+	BoardID    uuid.UUID `db:"board_id" json:"boardId"`
+	StorageKey string    `db:"storage_key" json:"storageKey"`
+	Version    int64     `db:"version" json:"version"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type Folder struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	UserID    string     `db:"user_id" json:"userId"`
+	ParentID  *uuid.UUID `db:"parent_id" json:"parentId"`
+	Name      string     `db:"name" json:"name"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time  `db:"updated_at" json:"updatedAt"`
 }
 
 type User struct {
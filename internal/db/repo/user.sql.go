@@ -9,6 +9,25 @@ import (
 	"context"
 )
 
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, name, email, email_verified, image, created_at, updated_at FROM "user" WHERE email = $1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.EmailVerified,
+		&i.Image,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getUserByID = `-- name: GetUserByID :one
 SELECT id, name, email, email_verified, image, created_at, updated_at FROM "user" WHERE id = $1
 `
@@ -27,3 +46,47 @@ func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
 	)
 	return i, err
 }
+
+const getUserUsage = `-- name: GetUserUsage :one
+SELECT
+    (SELECT count(*) FROM "board" b WHERE b.owner_id = $1 AND b.deleted_at IS NULL)::int AS board_count,
+    (SELECT count(*) FROM "board" b WHERE b.owner_id = $1 AND b.deleted_at IS NOT NULL)::int AS trashed_board_count,
+    (SELECT coalesce(sum(octet_length(b.elements::text)), 0) FROM "board" b WHERE b.owner_id = $1)::bigint AS elements_size,
+    (
+        SELECT coalesce(sum(f.size), 0)
+        FROM "board_file" f
+        JOIN "board" b ON b.id = f.board_id
+        WHERE b.owner_id = $1
+    )::bigint AS files_size
+`
+
+type GetUserUsageRow struct {
+	BoardCount        int32 `db:"board_count" json:"boardCount"`
+	TrashedBoardCount int32 `db:"trashed_board_count" json:"trashedBoardCount"`
+	ElementsSize      int64 `db:"elements_size" json:"elementsSize"`
+	FilesSize         int64 `db:"files_size" json:"filesSize"`
+}
+
+// Storage counts the elements of every board the user owns, trashed ones
+// included, and the files uploaded to them.
+func (q *Queries) GetUserUsage(ctx context.Context, ownerID string) (GetUserUsageRow, error) {
+	row := q.db.QueryRow(ctx, getUserUsage, ownerID)
+	var i GetUserUsageRow
+	err := row.Scan(
+		&i.BoardCount,
+		&i.TrashedBoardCount,
+		&i.ElementsSize,
+		&i.FilesSize,
+	)
+	return i, err
+}
+
+const lockUser = `-- name: LockUser :exec
+SELECT id FROM "user" WHERE id = $1 FOR UPDATE
+`
+
+// Serializes changes to what the user owns, such as their board count.
+func (q *Queries) LockUser(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, lockUser, id)
+	return err
+}
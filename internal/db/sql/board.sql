@@ -1,14 +1,100 @@
+-- name: CountBoardsByOwnerID :one
+SELECT count(*)::int FROM "board" WHERE owner_id = $1 AND deleted_at IS NULL;
+
 -- name: CreateBoard :one
-INSERT INTO "board" (name, owner_id) VALUES ($1, $2) RETURNING *;
+INSERT INTO "board" (name, owner_id, elements) VALUES ($1, $2, $3) RETURNING *;
 
 -- name: GetBoardByID :one
-SELECT * FROM "board" WHERE id = $1 AND owner_id = $2;
+SELECT * FROM "board" WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetBoardByIDForUpdate :one
+SELECT * FROM "board" WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;
+
+-- name: GetDeletedBoardsByOwnerID :many
+SELECT * FROM "board" WHERE owner_id = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC;
 
--- name: GetBoardsByUserID :many
-SELECT * FROM "board" WHERE owner_id = $1;
+-- name: ListBoardsByUserID :many
+-- Keyset-paginated board summaries. The cursor holds the sort key and id of
+-- the last row of the previous page; only the cursor column matching sort_by
+-- is read.
+SELECT
+    b.id, b.name, b.owner_id, b.created_at, b.updated_at, b.version, m.role, m.folder_id, m.tags, t.version AS thumbnail_version,
+    (
+        SELECT count(*)
+        FROM jsonb_array_elements(CASE WHEN jsonb_typeof(b.elements) = 'array' THEN b.elements ELSE '[]'::jsonb END) e
+        WHERE NOT COALESCE((e->>'isDeleted')::boolean, false)
+    )::int AS element_count
+FROM "board" b
+JOIN "board_member" m ON m.board_id = b.id
+LEFT JOIN "board_thumbnail" t ON t.board_id = b.id
+WHERE m.user_id = sqlc.arg(user_id)
+  AND b.deleted_at IS NULL
+  AND (sqlc.narg(search)::text IS NULL OR b.name ILIKE '%' || sqlc.narg(search)::text || '%')
+  AND (
+    sqlc.narg(cursor_id)::uuid IS NULL
+    OR (sqlc.arg(sort_by)::text = 'name' AND sqlc.arg(descending)::bool AND (b.name, b.id) < (sqlc.narg(cursor_name)::text, sqlc.narg(cursor_id)::uuid))
+    OR (sqlc.arg(sort_by)::text = 'name' AND NOT sqlc.arg(descending)::bool AND (b.name, b.id) > (sqlc.narg(cursor_name)::text, sqlc.narg(cursor_id)::uuid))
+    OR (sqlc.arg(sort_by)::text = 'created_at' AND sqlc.arg(descending)::bool AND (b.created_at, b.id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid))
+    OR (sqlc.arg(sort_by)::text = 'created_at' AND NOT sqlc.arg(descending)::bool AND (b.created_at, b.id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid))
+    OR (sqlc.arg(sort_by)::text = 'updated_at' AND sqlc.arg(descending)::bool AND (b.updated_at, b.id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid))
+    OR (sqlc.arg(sort_by)::text = 'updated_at' AND NOT sqlc.arg(descending)::bool AND (b.updated_at, b.id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_id)::uuid))
+  )
+  AND (sqlc.narg(folder_id)::uuid IS NULL OR m.folder_id = sqlc.narg(folder_id)::uuid)
+  AND (sqlc.narg(tag)::text IS NULL OR sqlc.narg(tag)::text = ANY(m.tags))
+ORDER BY
+    CASE WHEN sqlc.arg(sort_by)::text = 'name' AND sqlc.arg(descending)::bool THEN b.name END DESC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'name' AND NOT sqlc.arg(descending)::bool THEN b.name END ASC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'created_at' AND sqlc.arg(descending)::bool THEN b.created_at END DESC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'created_at' AND NOT sqlc.arg(descending)::bool THEN b.created_at END ASC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'updated_at' AND sqlc.arg(descending)::bool THEN b.updated_at END DESC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'updated_at' AND NOT sqlc.arg(descending)::bool THEN b.updated_at END ASC,
+    CASE WHEN sqlc.arg(descending)::bool THEN b.id END DESC,
+    CASE WHEN NOT sqlc.arg(descending)::bool THEN b.id END ASC
+LIMIT sqlc.arg(page_size);
 
 -- name: UpdateBoard :one
-UPDATE "board" SET name = $2, elements = $3 WHERE id = $1 AND owner_id = $4 RETURNING *;
+UPDATE "board" SET name = $2, elements = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING *;
+
+-- name: SoftDeleteBoard :execrows
+UPDATE "board" SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreBoard :one
+UPDATE "board" SET deleted_at = NULL WHERE id = $1 AND owner_id = $2 AND deleted_at IS NOT NULL RETURNING *;
+
+-- name: PurgeDeletedBoards :execrows
+DELETE FROM "board" WHERE deleted_at IS NOT NULL AND deleted_at < sqlc.arg(older_than)::timestamptz;
 
--- name: DeleteBoard :exec
-DELETE FROM "board" WHERE id = $1 AND owner_id = $2;
\ No newline at end of file
+-- name: SearchBoards :many
+-- Boards the user can access whose name or element text matches a
+-- websearch-style query, best match first. element_ids lists the live
+-- elements whose own text matches; snippet highlights the matches between
+-- chr(2) and chr(3) so the caller can escape the text before marking it up.
+SELECT
+    b.id, b.name, b.owner_id, b.updated_at, m.role,
+    ts_rank(b.search_vector, q.query)::real AS rank,
+    ts_headline(
+        'english',
+        COALESCE(matched.content, b.name),
+        q.query,
+        'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxFragments=3, MaxWords=20, MinWords=5'
+    ) AS snippet,
+    matched.element_ids
+FROM "board" b
+JOIN "board_member" m ON m.board_id = b.id
+CROSS JOIN websearch_to_tsquery('english', sqlc.arg(query)::text) AS q(query)
+CROSS JOIN LATERAL (
+    SELECT
+        COALESCE(array_agg(e.id ORDER BY e.ord), '{}')::text[] AS element_ids,
+        string_agg(e.content, ' … ' ORDER BY e.ord) AS content
+    FROM (
+        SELECT el->>'id' AS id, ord, concat_ws(' ', el->>'text', el#>>'{label,text}', el->>'name') AS content
+        FROM jsonb_array_elements(CASE WHEN jsonb_typeof(b.elements) = 'array' THEN b.elements ELSE '[]'::jsonb END) WITH ORDINALITY AS t(el, ord)
+        WHERE NOT COALESCE((el->>'isDeleted')::boolean, false)
+    ) e
+    WHERE to_tsvector('english', e.content) @@ q.query
+) matched
+WHERE m.user_id = sqlc.arg(user_id)
+  AND b.deleted_at IS NULL
+  AND b.search_vector @@ q.query
+ORDER BY rank DESC, b.updated_at DESC, b.id
+LIMIT sqlc.arg(page_size);
@@ -0,0 +1,32 @@
+-- name: CreateBoardFile :one
+-- A file id keeps its first upload; a conflicting insert returns no row.
+INSERT INTO "board_file" (board_id, file_id, mime_type, size, storage_key, created_by, content_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (board_id, file_id) DO NOTHING
+RETURNING *;
+
+-- name: GetBoardFile :one
+SELECT * FROM "board_file" WHERE board_id = $1 AND file_id = $2;
+
+-- name: ListBoardFiles :many
+SELECT * FROM "board_file" WHERE board_id = $1 ORDER BY created_at, file_id;
+
+-- name: ListUnreferencedBoardFiles :many
+-- Files that no element of their board, or of any revision of it, refers to.
+-- Recent uploads are skipped: clients upload before saving the element.
+SELECT f.*
+FROM "board_file" f
+WHERE f.created_at < sqlc.arg(created_before)::timestamptz
+  AND NOT EXISTS (
+    SELECT 1 FROM "board" b
+    WHERE b.id = f.board_id AND b.elements @> jsonb_build_array(jsonb_build_object('fileId', f.file_id))
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM "board_revision" r
+    WHERE r.board_id = f.board_id AND r.elements @> jsonb_build_array(jsonb_build_object('fileId', f.file_id))
+  )
+ORDER BY f.created_at
+LIMIT sqlc.arg(page_size);
+
+-- name: DeleteBoardFile :execrows
+DELETE FROM "board_file" WHERE board_id = $1 AND file_id = $2;
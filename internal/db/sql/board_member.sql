@@ -0,0 +1,45 @@
+-- name: CreateBoardMember :one
+INSERT INTO "board_member" (board_id, user_id, role, invited_by) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetBoardMemberRole :one
+SELECT m.role
+FROM "board_member" m
+JOIN "board" b ON b.id = m.board_id
+WHERE m.board_id = $1 AND m.user_id = $2 AND b.deleted_at IS NULL;
+
+-- name: ListBoardMembers :many
+SELECT m.board_id, m.user_id, m.role, m.invited_by, m.created_at, u.name, u.email, u.image
+FROM "board_member" m
+JOIN "user" u ON u.id = m.user_id
+WHERE m.board_id = $1
+ORDER BY m.created_at;
+
+-- name: UpdateBoardMemberRole :one
+UPDATE "board_member" SET role = $3 WHERE board_id = $1 AND user_id = $2 RETURNING *;
+
+-- name: DeleteBoardMember :execrows
+DELETE FROM "board_member" WHERE board_id = $1 AND user_id = $2;
+
+-- name: SetBoardMemberFolder :execrows
+UPDATE "board_member" SET folder_id = $3 WHERE board_id = $1 AND user_id = $2;
+
+-- name: AddBoardMemberTags :one
+UPDATE "board_member"
+SET tags = ARRAY(SELECT DISTINCT t FROM unnest(tags || sqlc.arg(tags)::text[]) AS t ORDER BY t)
+WHERE board_id = sqlc.arg(board_id) AND user_id = sqlc.arg(user_id)
+RETURNING tags;
+
+-- name: RemoveBoardMemberTag :one
+UPDATE "board_member"
+SET tags = array_remove(tags, sqlc.arg(tag)::text)
+WHERE board_id = sqlc.arg(board_id) AND user_id = sqlc.arg(user_id)
+RETURNING tags;
+
+-- name: ListUserTags :many
+SELECT t.tag::text AS tag, count(*)::int AS board_count
+FROM "board_member" m
+JOIN "board" b ON b.id = m.board_id
+CROSS JOIN unnest(m.tags) AS t(tag)
+WHERE m.user_id = $1 AND b.deleted_at IS NULL
+GROUP BY t.tag
+ORDER BY t.tag;
@@ -0,0 +1,32 @@
+-- name: CreateBoardRevision :one
+INSERT INTO "board_revision" (board_id, revision, elements, element_count, created_by)
+VALUES (
+    $1,
+    (SELECT COALESCE(MAX(revision), 0) + 1 FROM "board_revision" WHERE board_id = $1),
+    $2,
+    $3,
+    $4
+)
+RETURNING *;
+
+-- name: ListBoardRevisions :many
+SELECT r.id, r.board_id, r.revision, r.element_count, r.created_by, r.created_at, u.name AS created_by_name
+FROM "board_revision" r
+LEFT JOIN "user" u ON u.id = r.created_by
+WHERE r.board_id = $1
+ORDER BY r.revision DESC;
+
+-- name: GetBoardRevision :one
+SELECT * FROM "board_revision" WHERE board_id = $1 AND revision = $2;
+
+-- name: GetPreviousBoardRevision :one
+SELECT * FROM "board_revision" WHERE board_id = $1 AND revision < $2 ORDER BY revision DESC LIMIT 1;
+
+-- name: PruneBoardRevisions :exec
+DELETE FROM "board_revision"
+WHERE board_id = $1
+  AND revision < (SELECT MAX(revision) FROM "board_revision" WHERE board_id = $1)
+  AND (
+    revision <= (SELECT MAX(revision) FROM "board_revision" WHERE board_id = $1) - sqlc.arg(keep)::int
+    OR created_at < sqlc.arg(older_than)::timestamptz
+  );
@@ -0,0 +1,15 @@
+-- name: CreateBoardShareLink :one
+INSERT INTO "board_share_link" (board_id, token, created_by, expires_at) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: ListBoardShareLinks :many
+SELECT * FROM "board_share_link" WHERE board_id = $1 ORDER BY created_at DESC;
+
+-- name: DeleteBoardShareLink :execrows
+DELETE FROM "board_share_link" WHERE id = $1 AND board_id = $2;
+
+-- name: AccessBoardShareLink :one
+UPDATE "board_share_link"
+SET access_count = access_count + 1, last_accessed_at = CURRENT_TIMESTAMP
+WHERE token = $1 AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+  AND EXISTS (SELECT 1 FROM "board" b WHERE b.id = board_share_link.board_id AND b.deleted_at IS NULL)
+RETURNING *;
@@ -0,0 +1,16 @@
+-- name: CreateBoardTemplate :one
+INSERT INTO "board_template" (name, description, owner_id, elements) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetBoardTemplate :one
+SELECT * FROM "board_template" WHERE id = $1 AND (owner_id IS NULL OR owner_id = sqlc.arg(user_id)::text);
+
+-- name: ListBoardTemplates :many
+SELECT
+    id, name, description, owner_id, created_at,
+    jsonb_array_length(CASE WHEN jsonb_typeof(elements) = 'array' THEN elements ELSE '[]'::jsonb END)::int AS element_count
+FROM "board_template"
+WHERE owner_id IS NULL OR owner_id = sqlc.arg(user_id)::text
+ORDER BY owner_id IS NULL DESC, created_at DESC;
+
+-- name: DeleteBoardTemplate :execrows
+DELETE FROM "board_template" WHERE id = $1 AND owner_id = sqlc.arg(user_id)::text;
@@ -0,0 +1,29 @@
+-- name: UpsertBoardThumbnail :one
+-- A render of an older version never replaces a newer one.
+INSERT INTO "board_thumbnail" (board_id, storage_key, version) VALUES ($1, $2, $3)
+ON CONFLICT (board_id) DO UPDATE
+SET storage_key = EXCLUDED.storage_key, version = EXCLUDED.version, updated_at = CURRENT_TIMESTAMP
+WHERE board_thumbnail.version < EXCLUDED.version
+RETURNING *;
+
+-- name: GetBoardThumbnail :one
+SELECT * FROM "board_thumbnail" WHERE board_id = $1;
+
+-- name: ListOrphanedBoardThumbnails :many
+SELECT t.*
+FROM "board_thumbnail" t
+WHERE NOT EXISTS (SELECT 1 FROM "board" b WHERE b.id = t.board_id)
+LIMIT $1;
+
+-- name: ListStaleBoardThumbnails :many
+-- Boards with no thumbnail, or one drawn from an older version, most
+-- recently edited first.
+SELECT b.id
+FROM "board" b
+LEFT JOIN "board_thumbnail" t ON t.board_id = b.id
+WHERE b.deleted_at IS NULL AND (t.version IS NULL OR t.version < b.version)
+ORDER BY b.updated_at DESC
+LIMIT $1;
+
+-- name: DeleteBoardThumbnail :execrows
+DELETE FROM "board_thumbnail" WHERE board_id = $1;
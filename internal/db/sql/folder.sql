@@ -0,0 +1,38 @@
+-- name: CreateFolder :one
+INSERT INTO "folder" (user_id, parent_id, name) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: GetFolder :one
+SELECT * FROM "folder" WHERE id = $1 AND user_id = $2;
+
+-- name: ListFolders :many
+SELECT
+    f.id, f.user_id, f.parent_id, f.name, f.created_at, f.updated_at,
+    (
+        SELECT count(*)
+        FROM "board_member" m
+        JOIN "board" b ON b.id = m.board_id
+        WHERE m.folder_id = f.id AND m.user_id = f.user_id AND b.deleted_at IS NULL
+    )::int AS board_count
+FROM "folder" f
+WHERE f.user_id = $1
+ORDER BY f.name, f.id;
+
+-- name: LockUserFolders :exec
+-- Serializes changes to the shape of a user's folder tree.
+SELECT id FROM "folder" WHERE user_id = $1 FOR UPDATE;
+
+-- name: ListFolderAncestorIDs :many
+-- The folder itself followed by every folder above it, nearest first. A
+-- loop in the parents ends the list instead of recursing forever.
+WITH RECURSIVE ancestors AS (
+    SELECT f.id, f.parent_id, 0 AS depth FROM "folder" f WHERE f.id = $1
+    UNION ALL
+    SELECT f.id, f.parent_id, a.depth + 1 FROM "folder" f JOIN ancestors a ON f.id = a.parent_id
+) CYCLE id SET is_cycle USING path
+SELECT id FROM ancestors WHERE NOT is_cycle ORDER BY depth;
+
+-- name: UpdateFolder :one
+UPDATE "folder" SET name = $3, parent_id = $4, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND user_id = $2 RETURNING *;
+
+-- name: DeleteFolder :execrows
+DELETE FROM "folder" WHERE id = $1 AND user_id = $2;
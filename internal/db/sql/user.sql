@@ -1,2 +1,23 @@
 -- name: GetUserByID :one
 SELECT * FROM "user" WHERE id = $1;
+
+-- name: GetUserByEmail :one
+SELECT * FROM "user" WHERE email = $1;
+
+-- name: GetUserUsage :one
+-- Storage counts the elements of every board the user owns, trashed ones
+-- included, and the files uploaded to them.
+SELECT
+    (SELECT count(*) FROM "board" b WHERE b.owner_id = $1 AND b.deleted_at IS NULL)::int AS board_count,
+    (SELECT count(*) FROM "board" b WHERE b.owner_id = $1 AND b.deleted_at IS NOT NULL)::int AS trashed_board_count,
+    (SELECT coalesce(sum(octet_length(b.elements::text)), 0) FROM "board" b WHERE b.owner_id = $1)::bigint AS elements_size,
+    (
+        SELECT coalesce(sum(f.size), 0)
+        FROM "board_file" f
+        JOIN "board" b ON b.id = f.board_id
+        WHERE b.owner_id = $1
+    )::bigint AS files_size;
+
+-- name: LockUser :exec
+-- Serializes changes to what the user owns, such as their board count.
+SELECT id FROM "user" WHERE id = $1 FOR UPDATE;
@@ -2,6 +2,9 @@ package dto
 
 import (
 	"encoding/json"
+	"time"
+
+	"draw/pkg/excalidraw"
 
 	"github.com/google/uuid"
 )
@@ -12,6 +15,10 @@ type Board struct {
 	Name string `json:"name"`
 	OwnerID string `json:"ownerId"`
 	Elements json.RawMessage `json:"elements"`
+	Version int64 `json:"version"`
+	// Role is the requesting user's role on the board.
+	Role string `json:"role,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 }
 
 // Request
@@ -21,22 +28,82 @@ type GetBoardRequest struct {
 	UserID string `json:"-"`
 }
 
+// BoardSummary is the list projection of a board; it leaves out elements.
+type BoardSummary struct {
+	ID uuid.UUID `json:"id"`
+	Name string `json:"name"`
+	OwnerID string `json:"ownerId"`
+	Role string `json:"role"`
+	Version int64 `json:"version"`
+	ElementCount int32 `json:"elementCount"`
+	// FolderID and Tags are the requesting user's own filing of the board.
+	FolderID *uuid.UUID `json:"folderId,omitempty"`
+	Tags []string `json:"tags"`
+	// ThumbnailURL is unset until a thumbnail has been drawn.
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
 type GetBoardsByUserIDRequest struct {
 	UserID string `json:"-"`
+	// Cursor is the nextCursor of the previous page.
+	Cursor string `form:"cursor"`
+	Search string `form:"q"`
+	Folder string `form:"folder" binding:"omitempty,uuid"`
+	Tag string `form:"tag"`
+	Sort string `form:"sort" binding:"omitempty,oneof=updated_at created_at name"`
+	Order string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
 type CreateBoardRequest struct {
 	UserID string `json:"-"`
 	Name string `json:"name" binding:"required"`
+	Elements json.RawMessage `json:"elements,omitempty"`
+	// TemplateID seeds the board from a template instead of Elements.
+	TemplateID string `json:"templateId,omitempty"`
+	// Mermaid seeds the board from a Mermaid diagram instead of Elements.
+	Mermaid string `json:"mermaid,omitempty" binding:"max=100000"`
+}
+
+type DuplicateBoardRequest struct {
+	BoardID string `json:"-"`
+	UserID string `json:"-"`
+	// Name defaults to "Copy of <original name>".
+	Name string `json:"name,omitempty"`
 }
 
 type UpdateBoardRequest struct {
 	BoardID string `json:"-"`
 	UserID string `json:"-"`
+	// IfMatch is the board version the client based its update on, taken
+	// from the If-Match header. Nil skips the check.
+	IfMatch *int64 `json:"-"`
 	Name string `json:"name,omitempty"`
 	Elements json.RawMessage `json:"elements,omitempty"`
 }
 
+type PatchBoardElementsRequest struct {
+	BoardID string `json:"-"`
+	UserID string `json:"-"`
+	Operations []excalidraw.Operation `json:"operations" binding:"required,min=1"`
+}
+
+type DeleteBoardRequest struct {
+	BoardID string `json:"-"`
+	UserID string `json:"-"`
+}
+
+type GetTrashRequest struct {
+	UserID string `json:"-"`
+}
+
+type RestoreBoardRequest struct {
+	BoardID string `json:"-"`
+	UserID string `json:"-"`
+}
+
 // Response
 type CreateBoardResponse struct {
 	BoardID uuid.UUID `json:"boardId"`
@@ -44,9 +111,29 @@ type CreateBoardResponse struct {
 
 type GetBoardResponse struct {
 	Board Board `json:"board"`
-	Token string `json:"token"`
 }
 
 type GetBoardsByUserIDResponse struct {
+	Boards []BoardSummary `json:"boards"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type GetTrashResponse struct {
 	Boards []Board `json:"boards"`
+}
+
+type PatchBoardElementsResponse struct {
+	Applied []string `json:"applied"`
+	Rejected []excalidraw.Rejection `json:"rejected"`
+}
+
+type BoardConflictDetails struct {
+	Version int64 `json:"version"`
+	Elements json.RawMessage `json:"elements"`
+}
+
+type InvalidElementsDetails struct {
+	ElementIDs []string `json:"elementIds"`
+	Issues []excalidraw.Issue `json:"issues"`
 }
\ No newline at end of file
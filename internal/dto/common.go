@@ -1,8 +1,9 @@
 package dto
 
 type ErrorResponse struct {
-	Message string `json:"message"`
-	Error   string `json:"error,omitempty"`
+	Message string      `json:"message"`
+	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 type SuccessResponse struct {
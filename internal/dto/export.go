@@ -0,0 +1,28 @@
+package dto
+
+// Request
+
+type ExportBoardRequest struct {
+	BoardID string `json:"-" form:"-"`
+	UserID  string `json:"-" form:"-"`
+	// Background defaults to true.
+	Background      *bool    `form:"background"`
+	BackgroundColor string   `form:"backgroundColor"`
+	Padding         *float64 `form:"padding" binding:"omitempty,min=0,max=1000"`
+	DarkMode        bool     `form:"dark"`
+	// FrameID exports only the given frame.
+	FrameID string `form:"frame"`
+	// Scale multiplies the pixel size of PNG exports.
+	Scale *float64 `form:"scale" binding:"omitempty,min=0.1,max=4"`
+}
+
+// Response
+
+type ExportBoardResponse struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+	// Skipped counts the elements the format could not represent. Text
+	// formats list them, with the reason, in comments at the end.
+	Skipped int
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"io"
+	"time"
+)
+
+// BoardFile is binary data, such as an image, referenced by the fileId of
+// board elements.
+type BoardFile struct {
+	ID        string    `json:"id"`
+	MimeType  string    `json:"mimeType"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Request
+
+type UploadBoardFileRequest struct {
+	BoardID string    `json:"-"`
+	UserID  string    `json:"-"`
+	FileID  string    `json:"-"`
+	Body    io.Reader `json:"-"`
+}
+
+type GetBoardFileRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+	FileID  string `json:"-"`
+}
+
+type ListBoardFilesRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+// Response
+
+// BoardFileContent streams a stored file. The caller closes Body.
+type BoardFileContent struct {
+	Body     io.ReadCloser
+	MimeType string
+	Size     int64
+}
+
+type ListBoardFilesResponse struct {
+	Files []BoardFile `json:"files"`
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Folder struct {
+	ID         uuid.UUID  `json:"id"`
+	ParentID   *uuid.UUID `json:"parentId,omitempty"`
+	Name       string     `json:"name"`
+	BoardCount int32      `json:"boardCount"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// Request
+
+type ListFoldersRequest struct {
+	UserID string `json:"-"`
+}
+
+type CreateFolderRequest struct {
+	UserID   string `json:"-"`
+	Name     string `json:"name" binding:"required,max=255"`
+	ParentID string `json:"parentId,omitempty" binding:"omitempty,uuid"`
+}
+
+type UpdateFolderRequest struct {
+	FolderID string  `json:"-"`
+	UserID   string  `json:"-"`
+	Name     *string `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	// ParentID moves the folder; an empty string moves it to the top level.
+	ParentID *string `json:"parentId,omitempty"`
+}
+
+type DeleteFolderRequest struct {
+	FolderID string `json:"-"`
+	UserID   string `json:"-"`
+}
+
+type MoveBoardRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+	// FolderID files the board for the requesting user; empty unfiles it.
+	FolderID string `json:"folderId" binding:"omitempty,uuid"`
+}
+
+// Response
+
+type ListFoldersResponse struct {
+	Folders []Folder `json:"folders"`
+}
+
+type MoveBoardResponse struct {
+	BoardID  uuid.UUID  `json:"boardId"`
+	FolderID *uuid.UUID `json:"folderId"`
+}
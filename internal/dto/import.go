@@ -0,0 +1,18 @@
+package dto
+
+// Request
+
+type ImportMermaidRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+	// Source is a Mermaid flowchart or sequence diagram.
+	Source string `json:"source" binding:"required,max=100000"`
+}
+
+// Response
+
+type ImportMermaidResponse struct {
+	Board Board `json:"board"`
+	// ElementIDs are the ids of the elements added to the board.
+	ElementIDs []string `json:"elementIds"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BoardMember struct {
+	BoardID   uuid.UUID `json:"boardId"`
+	UserID    string    `json:"userId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Image     *string   `json:"image,omitempty"`
+	Role      string    `json:"role"`
+	InvitedBy *string   `json:"invitedBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Request
+
+type ListBoardMembersRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+type AddBoardMemberRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+	Email   string `json:"email" binding:"required,email"`
+	Role    string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+type UpdateBoardMemberRequest struct {
+	BoardID  string `json:"-"`
+	UserID   string `json:"-"`
+	MemberID string `json:"-"`
+	Role     string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+type RemoveBoardMemberRequest struct {
+	BoardID  string `json:"-"`
+	UserID   string `json:"-"`
+	MemberID string `json:"-"`
+}
+
+// Response
+
+type ListBoardMembersResponse struct {
+	Members []BoardMember `json:"members"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"draw/pkg/excalidraw"
+
+	"github.com/google/uuid"
+)
+
+type BoardRevision struct {
+	ID            uuid.UUID       `json:"id"`
+	BoardID       uuid.UUID       `json:"boardId"`
+	Revision      int32           `json:"revision"`
+	ElementCount  int32           `json:"elementCount"`
+	CreatedBy     *string         `json:"createdBy"`
+	CreatedByName *string         `json:"createdByName,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Elements      json.RawMessage `json:"elements,omitempty"`
+}
+
+// Request
+
+type ListBoardRevisionsRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+type GetBoardRevisionRequest struct {
+	BoardID  string `json:"-"`
+	UserID   string `json:"-"`
+	Revision int32  `json:"-"`
+	// Compare selects what the revision is diffed against: "previous"
+	// (default), "current" or another revision number.
+	Compare string `json:"-"`
+}
+
+type RestoreBoardRevisionRequest struct {
+	BoardID  string `json:"-"`
+	UserID   string `json:"-"`
+	Revision int32  `json:"-"`
+}
+
+// Response
+
+type ListBoardRevisionsResponse struct {
+	Revisions []BoardRevision `json:"revisions"`
+}
+
+type GetBoardRevisionResponse struct {
+	Revision   BoardRevision   `json:"revision"`
+	ComparedTo string          `json:"comparedTo"`
+	Diff       excalidraw.Diff `json:"diff"`
+}
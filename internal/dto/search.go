@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SearchResult struct {
+	BoardID   uuid.UUID `json:"boardId"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"ownerId"`
+	Role      string    `json:"role"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Snippet is HTML-escaped text with matches wrapped in <mark>.
+	Snippet string `json:"snippet"`
+	// ElementIDs are the elements whose text matches the query.
+	ElementIDs []string `json:"elementIds"`
+}
+
+// Request
+
+type SearchRequest struct {
+	UserID string `json:"-" form:"-"`
+	// Query accepts web search syntax: quoted phrases, "or" and -exclusions.
+	Query string `form:"q" binding:"required,max=200"`
+	Limit int32  `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// Response
+
+type SearchResponse struct {
+	Results []SearchResult `json:"results"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+// BoardSession is the state of a board's live voice and sync session.
+type BoardSession struct {
+	// Active is false when no session is running for the board; the other
+	// fields are then empty.
+	Active bool `json:"active"`
+	// BotState is the bot's connection to the room: "connected",
+	// "reconnecting" or "disconnected".
+	BotState     string               `json:"botState"`
+	Participants []SessionParticipant `json:"participants"`
+	// VoiceReplies is set when the bot speaks its replies as well as
+	// writing them.
+	VoiceReplies bool `json:"voiceReplies"`
+}
+
+type SessionParticipant struct {
+	// Identity is the participant's user id.
+	Identity   string `json:"identity"`
+	Name       string `json:"name"`
+	Muted      bool   `json:"muted"`
+	CanPublish bool   `json:"canPublish"`
+}
+
+// Request
+
+type BoardSessionRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+type UpdateBoardSessionRequest struct {
+	BoardID      string `json:"-"`
+	UserID       string `json:"-"`
+	VoiceReplies *bool  `json:"voiceReplies" binding:"required"`
+}
+
+// Response
+
+type JoinBoardSessionResponse struct {
+	// Token admits the caller to the board's LiveKit room.
+	Token   string       `json:"token"`
+	Session BoardSession `json:"session"`
+}
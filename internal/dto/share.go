@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShareLink struct {
+	ID             uuid.UUID  `json:"id"`
+	BoardID        uuid.UUID  `json:"boardId"`
+	Token          string     `json:"token"`
+	CreatedBy      *string    `json:"createdBy,omitempty"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	AccessCount    int64      `json:"accessCount"`
+	LastAccessedAt *time.Time `json:"lastAccessedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// SharedBoard is the read-only view of a board served through a share link.
+type SharedBoard struct {
+	ID       uuid.UUID       `json:"id"`
+	Name     string          `json:"name"`
+	Elements json.RawMessage `json:"elements"`
+	Version  int64           `json:"version"`
+}
+
+// Request
+
+type CreateShareLinkRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+	// ExpiresAt is optional; links without it stay valid until revoked.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type ListShareLinksRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+type RevokeShareLinkRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+	LinkID  string `json:"-"`
+}
+
+type GetSharedBoardRequest struct {
+	Token string `json:"-"`
+}
+
+// Response
+
+type ListShareLinksResponse struct {
+	Links []ShareLink `json:"links"`
+}
+
+type GetSharedBoardResponse struct {
+	Board SharedBoard `json:"board"`
+}
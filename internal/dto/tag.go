@@ -0,0 +1,37 @@
+package dto
+
+import "github.com/google/uuid"
+
+type Tag struct {
+	Name       string `json:"name"`
+	BoardCount int32  `json:"boardCount"`
+}
+
+// Request
+
+type ListTagsRequest struct {
+	UserID string `json:"-"`
+}
+
+type TagBoardRequest struct {
+	BoardID string   `json:"-"`
+	UserID  string   `json:"-"`
+	Tags    []string `json:"tags" binding:"required,min=1"`
+}
+
+type UntagBoardRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+	Tag     string `json:"-"`
+}
+
+// Response
+
+type ListTagsResponse struct {
+	Tags []Tag `json:"tags"`
+}
+
+type BoardTagsResponse struct {
+	BoardID uuid.UUID `json:"boardId"`
+	Tags    []string  `json:"tags"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Template struct {
+	ID           uuid.UUID       `json:"id"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	OwnerID      *string         `json:"ownerId,omitempty"`
+	BuiltIn      bool            `json:"builtIn"`
+	ElementCount int32           `json:"elementCount"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	Elements     json.RawMessage `json:"elements,omitempty"`
+}
+
+// Request
+
+type ListTemplatesRequest struct {
+	UserID string `json:"-"`
+}
+
+type GetTemplateRequest struct {
+	UserID     string `json:"-"`
+	TemplateID string `json:"-"`
+}
+
+// CreateTemplateRequest saves a template either from an existing board or
+// from elements supplied directly; exactly one of BoardID and Elements is set.
+type CreateTemplateRequest struct {
+	UserID      string          `json:"-"`
+	Name        string          `json:"name" binding:"required"`
+	Description string          `json:"description"`
+	BoardID     string          `json:"boardId,omitempty"`
+	Elements    json.RawMessage `json:"elements,omitempty"`
+}
+
+type DeleteTemplateRequest struct {
+	UserID     string `json:"-"`
+	TemplateID string `json:"-"`
+}
+
+// Response
+
+type ListTemplatesResponse struct {
+	Templates []Template `json:"templates"`
+}
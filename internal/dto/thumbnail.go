@@ -0,0 +1,25 @@
+package dto
+
+// Request
+
+type GetBoardThumbnailRequest struct {
+	BoardID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+// GetSignedBoardThumbnailRequest loads a thumbnail through a URL from the
+// board list, which stands in for the caller's credentials.
+type GetSignedBoardThumbnailRequest struct {
+	BoardID   string `form:"-"`
+	Version   int64  `form:"v" binding:"required"`
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"sig" binding:"required"`
+}
+
+// Response
+
+// BoardThumbnail is a PNG preview of a board at Version.
+type BoardThumbnail struct {
+	Data    []byte
+	Version int64
+}
@@ -3,4 +3,31 @@ package dto
 type UserResponse struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
-}
\ No newline at end of file
+}
+
+// Request
+
+type GetUserUsageRequest struct {
+	UserID string `json:"-"`
+}
+
+// Response
+
+// UserUsage is what a user's boards consume next to the limits that apply.
+type UserUsage struct {
+	Boards        int32 `json:"boards"`
+	TrashedBoards int32 `json:"trashedBoards"`
+	// StorageBytes is the size of the user's board elements and uploaded
+	// files, trashed boards included.
+	StorageBytes int64      `json:"storageBytes"`
+	Limits       UserLimits `json:"limits"`
+}
+
+// UserLimits mirrors the server configuration. Zero means unlimited.
+type UserLimits struct {
+	Boards           int   `json:"boards"`
+	ElementsPerBoard int   `json:"elementsPerBoard"`
+	TextLength       int   `json:"textLength"`
+	RequestSize      int64 `json:"requestSize"`
+	FileSize         int64 `json:"fileSize"`
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// purgeDeletedBoards periodically hard-deletes boards whose trash retention
+// has elapsed. It returns when ctx is cancelled.
+func (s *Server) purgeDeletedBoards(ctx context.Context) {
+	interval := s.App.Config.Board.PurgeInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.App.Service.BoardService.PurgeDeletedBoards(ctx)
+		if err != nil {
+			s.App.Log.Error(ctx, "Failed to purge deleted boards", "error", err)
+		} else if purged > 0 {
+			s.App.Log.Info(ctx, "Purged deleted boards", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collectUnreferencedFiles periodically deletes uploaded files that no board
+// element refers to any more, and the thumbnails of purged boards. It returns
+// when ctx is cancelled.
+func (s *Server) collectUnreferencedFiles(ctx context.Context) {
+	interval := s.App.Config.Storage.FileGCInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		collected, err := s.App.Service.FileService.CollectUnreferencedFiles(ctx)
+		if err != nil {
+			s.App.Log.Error(ctx, "Failed to collect unreferenced files", "error", err)
+		} else if collected > 0 {
+			s.App.Log.Info(ctx, "Collected unreferenced files", "count", collected)
+		}
+		collected, err = s.App.Service.ThumbnailService.CollectOrphanedThumbnails(ctx)
+		if err != nil {
+			s.App.Log.Error(ctx, "Failed to collect orphaned thumbnails", "error", err)
+		} else if collected > 0 {
+			s.App.Log.Info(ctx, "Collected orphaned thumbnails", "count", collected)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// staleThumbnailInterval is how often renderThumbnails looks for boards whose
+// thumbnail no save has scheduled.
+const staleThumbnailInterval = 5 * time.Minute
+
+// renderThumbnails draws the board thumbnails that saves have scheduled, and
+// periodically schedules the ones that are out of date for any other reason.
+// It returns when ctx is cancelled.
+func (s *Server) renderThumbnails(ctx context.Context) {
+	// Poll often enough that a thumbnail lags its delay by a fraction of it.
+	interval := max(s.App.Config.Board.ThumbnailDelay/4, 250*time.Millisecond)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	staleTicker := time.NewTicker(staleThumbnailInterval)
+	defer staleTicker.Stop()
+
+	s.scheduleStaleThumbnails(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-staleTicker.C:
+			s.scheduleStaleThumbnails(ctx)
+			continue
+		case <-ticker.C:
+		}
+
+		rendered, err := s.App.Service.ThumbnailService.RenderDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.App.Log.Error(ctx, "Failed to render thumbnails", "error", err)
+		}
+		if rendered > 0 {
+			s.App.Log.Debug(ctx, "Rendered thumbnails", "count", rendered)
+		}
+	}
+}
+
+func (s *Server) scheduleStaleThumbnails(ctx context.Context) {
+	scheduled, err := s.App.Service.ThumbnailService.ScheduleStale(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.App.Log.Error(ctx, "Failed to schedule stale thumbnails", "error", err)
+	}
+	if scheduled > 0 {
+		s.App.Log.Debug(ctx, "Scheduled stale thumbnails", "count", scheduled)
+	}
+}
@@ -52,6 +52,12 @@ func(s *Server) Run() error {
 	done := make(chan bool, 1)
 	go s.gracefulShutdown(done)
 
+	jobsCtx, stopJobs := context.WithCancel(s.ctx)
+	defer stopJobs()
+	go s.purgeDeletedBoards(jobsCtx)
+	go s.collectUnreferencedFiles(jobsCtx)
+	go s.renderThumbnails(jobsCtx)
+
 	s.App.Log.Info(s.ctx, "Starting server", "port", s.App.Config.Server.Port)
 	if err := s.httpServer.ListenAndServe(); err != nil && err != httpSrv.ErrServerClosed {
 		s.App.Log.Error(s.ctx, "Could not start server", "error", err)
@@ -79,6 +85,11 @@ func (s *Server) gracefulShutdown(done chan bool) {
 	if err := s.httpServer.Shutdown(ctx); err != nil {
 		s.App.Log.Error(s.ctx, "Server forced to shutdown with error", "error", err)
 	}
+	// Stop bots after the last request that could start one has finished;
+	// stopping flushes unsaved live edits.
+	if err := s.App.Sessions.Shutdown(ctx); err != nil {
+		s.App.Log.Error(s.ctx, "Failed to stop live sessions", "error", err)
+	}
 
 	done <- true
 }
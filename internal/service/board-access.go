@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"draw/internal/db/repo"
+
+	"github.com/google/uuid"
+)
+
+// Board member roles, from most to least privileged.
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+var roleRank = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// authorizeBoard checks that userID is a member of the board with at least
+// minRole and returns the member's role. Non-members get ErrNotFound so that
+// board ids cannot be probed.
+func authorizeBoard(ctx context.Context, q *repo.Queries, boardID uuid.UUID, userID string, minRole string) (string, error) {
+	role, err := q.GetBoardMemberRole(ctx, repo.GetBoardMemberRoleParams{
+		BoardID: boardID,
+		UserID:  userID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+	if roleRank[role] < roleRank[minRole] {
+		return "", fmt.Errorf("%s role required: %w", minRole, ErrForbidden)
+	}
+	return role, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"draw/internal/db/repo"
+
+	"github.com/google/uuid"
+)
+
+// Board list sort keys.
+const (
+	SortUpdatedAt = "updated_at"
+	SortCreatedAt = "created_at"
+	SortName      = "name"
+)
+
+const (
+	defaultBoardPageSize = 20
+	maxBoardPageSize     = 100
+)
+
+// boardCursor marks the last row of a page. It records the sort it was issued
+// for so that a cursor cannot be replayed against a different ordering.
+type boardCursor struct {
+	Sort       string    `json:"s"`
+	Descending bool      `json:"d"`
+	Value      string    `json:"v"`
+	ID         uuid.UUID `json:"id"`
+}
+
+func encodeBoardCursor(sortBy string, descending bool, row repo.ListBoardsByUserIDRow) string {
+	cursor := boardCursor{Sort: sortBy, Descending: descending, ID: row.ID}
+	switch sortBy {
+	case SortName:
+		cursor.Value = row.Name
+	case SortCreatedAt:
+		cursor.Value = row.CreatedAt.Format(time.RFC3339Nano)
+	default:
+		cursor.Value = row.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// applyBoardCursor decodes an opaque cursor into the keyset columns of params.
+func applyBoardCursor(params *repo.ListBoardsByUserIDParams, encoded string) error {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("malformed cursor: %w", ErrInvalidInput)
+	}
+	var cursor boardCursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return fmt.Errorf("malformed cursor: %w", ErrInvalidInput)
+	}
+	if cursor.Sort != params.SortBy || cursor.Descending != params.Descending {
+		return fmt.Errorf("cursor was issued for a different sort order: %w", ErrInvalidInput)
+	}
+
+	switch cursor.Sort {
+	case SortName:
+		params.CursorName = &cursor.Value
+	default:
+		t, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return fmt.Errorf("malformed cursor: %w", ErrInvalidInput)
+		}
+		params.CursorTime = &t
+	}
+	params.CursorID = &cursor.ID
+	return nil
+}
+
+// likePattern escapes LIKE wildcards so that search text matches literally.
+func likePattern(search string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(search)
+}
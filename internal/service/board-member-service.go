@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+	"draw/pkg/livekit"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BoardMemberService interface {
+	ListMembers(ctx context.Context, req dto.ListBoardMembersRequest) (*dto.ListBoardMembersResponse, error)
+	AddMember(ctx context.Context, req dto.AddBoardMemberRequest) (*dto.BoardMember, error)
+	UpdateMember(ctx context.Context, req dto.UpdateBoardMemberRequest) (*dto.BoardMember, error)
+	RemoveMember(ctx context.Context, req dto.RemoveBoardMemberRequest) error
+}
+
+type boardMemberService struct {
+	queries  *repo.Queries
+	db       *pgxpool.Pool
+	sessions *livekit.Manager
+	config   *config.AppConfig
+}
+
+func NewBoardMemberService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	sessions *livekit.Manager,
+	config *config.AppConfig,
+) BoardMemberService {
+	return &boardMemberService{
+		db:       db,
+		queries:  queries,
+		sessions: sessions,
+		config:   config,
+	}
+}
+
+func (s *boardMemberService) ListMembers(ctx context.Context, req dto.ListBoardMembersRequest) (*dto.ListBoardMembersResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListBoardMembers(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	members := make([]dto.BoardMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, dto.BoardMember{
+			BoardID:   row.BoardID,
+			UserID:    row.UserID,
+			Name:      row.Name,
+			Email:     row.Email,
+			Image:     row.Image,
+			Role:      row.Role,
+			InvitedBy: row.InvitedBy,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return &dto.ListBoardMembersResponse{
+		Members: members,
+	}, nil
+}
+
+func (s *boardMemberService) AddMember(ctx context.Context, req dto.AddBoardMemberRequest) (*dto.BoardMember, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := authorizeBoard(ctx, qtx, boardID, req.UserID, RoleOwner); err != nil {
+		return nil, err
+	}
+
+	user, err := qtx.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user %q: %w", req.Email, dbError(err))
+	}
+
+	_, err = qtx.GetBoardMemberRole(ctx, repo.GetBoardMemberRoleParams{
+		BoardID: boardID,
+		UserID:  user.ID,
+	})
+	if err == nil {
+		return nil, fmt.Errorf("user is already a member of this board: %w", ErrConflict)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	member, err := qtx.CreateBoardMember(ctx, repo.CreateBoardMemberParams{
+		BoardID:   boardID,
+		UserID:    user.ID,
+		Role:      req.Role,
+		InvitedBy: &req.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &dto.BoardMember{
+		BoardID:   member.BoardID,
+		UserID:    member.UserID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Image:     user.Image,
+		Role:      member.Role,
+		InvitedBy: member.InvitedBy,
+		CreatedAt: member.CreatedAt,
+	}, nil
+}
+
+func (s *boardMemberService) UpdateMember(ctx context.Context, req dto.UpdateBoardMemberRequest) (*dto.BoardMember, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := authorizeBoard(ctx, qtx, boardID, req.UserID, RoleOwner); err != nil {
+		return nil, err
+	}
+
+	role, err := qtx.GetBoardMemberRole(ctx, repo.GetBoardMemberRoleParams{
+		BoardID: boardID,
+		UserID:  req.MemberID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %w", dbError(err))
+	}
+	if role == RoleOwner {
+		return nil, fmt.Errorf("the owner's role cannot be changed: %w", ErrForbidden)
+	}
+
+	member, err := qtx.UpdateBoardMemberRole(ctx, repo.UpdateBoardMemberRoleParams{
+		BoardID: boardID,
+		UserID:  req.MemberID,
+		Role:    req.Role,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update member: %w", dbError(err))
+	}
+
+	user, err := qtx.GetUserByID(ctx, member.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", dbError(err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.sessions.RefreshAccess(ctx, boardID.String(), req.MemberID)
+
+	return &dto.BoardMember{
+		BoardID:   member.BoardID,
+		UserID:    member.UserID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Image:     user.Image,
+		Role:      member.Role,
+		InvitedBy: member.InvitedBy,
+		CreatedAt: member.CreatedAt,
+	}, nil
+}
+
+// RemoveMember removes a member from a board. Owners may remove anyone but
+// themselves; any other member may remove only themselves to leave the board.
+func (s *boardMemberService) RemoveMember(ctx context.Context, req dto.RemoveBoardMemberRequest) error {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return err
+	}
+
+	minRole := RoleOwner
+	if req.MemberID == req.UserID {
+		minRole = RoleViewer
+	}
+	role, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, minRole)
+	if err != nil {
+		return err
+	}
+	if req.MemberID == req.UserID && role == RoleOwner {
+		return fmt.Errorf("the owner cannot leave their own board: %w", ErrForbidden)
+	}
+
+	removed, err := s.queries.DeleteBoardMember(ctx, repo.DeleteBoardMemberParams{
+		BoardID: boardID,
+		UserID:  req.MemberID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("failed to remove member: %w", ErrNotFound)
+	}
+	// Members removed or demoted lose live access straight away rather
+	// than when their room token expires.
+	s.sessions.RefreshAccess(ctx, boardID.String(), req.MemberID)
+	return nil
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+	"draw/pkg/excalidraw"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BoardRevisionService interface {
+	ListRevisions(ctx context.Context, req dto.ListBoardRevisionsRequest) (*dto.ListBoardRevisionsResponse, error)
+	GetRevision(ctx context.Context, req dto.GetBoardRevisionRequest) (*dto.GetBoardRevisionResponse, error)
+	RestoreRevision(ctx context.Context, req dto.RestoreBoardRevisionRequest) (*dto.GetBoardResponse, error)
+}
+
+type boardRevisionService struct {
+	queries    *repo.Queries
+	db         *pgxpool.Pool
+	thumbnails ThumbnailService
+	config     *config.AppConfig
+}
+
+func NewBoardRevisionService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	thumbnails ThumbnailService,
+	config *config.AppConfig,
+) BoardRevisionService {
+	return &boardRevisionService{
+		db:         db,
+		queries:    queries,
+		thumbnails: thumbnails,
+		config:     config,
+	}
+}
+
+func (s *boardRevisionService) ListRevisions(ctx context.Context, req dto.ListBoardRevisionsRequest) (*dto.ListBoardRevisionsResponse, error) {
+	board, err := s.getBoard(ctx, req.BoardID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListBoardRevisions(ctx, board.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	revisions := make([]dto.BoardRevision, 0, len(rows))
+	for _, row := range rows {
+		revisions = append(revisions, dto.BoardRevision{
+			ID:            row.ID,
+			BoardID:       row.BoardID,
+			Revision:      row.Revision,
+			ElementCount:  row.ElementCount,
+			CreatedBy:     row.CreatedBy,
+			CreatedByName: row.CreatedByName,
+			CreatedAt:     row.CreatedAt,
+		})
+	}
+	return &dto.ListBoardRevisionsResponse{
+		Revisions: revisions,
+	}, nil
+}
+
+func (s *boardRevisionService) GetRevision(ctx context.Context, req dto.GetBoardRevisionRequest) (*dto.GetBoardRevisionResponse, error) {
+	board, err := s.getBoard(ctx, req.BoardID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.queries.GetBoardRevision(ctx, repo.GetBoardRevisionParams{
+		BoardID:  board.ID,
+		Revision: req.Revision,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", dbError(err))
+	}
+
+	comparedTo, baseline, err := s.comparisonBaseline(ctx, board, revision, req.Compare)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := excalidraw.Parse(baseline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s elements: %w", comparedTo, err)
+	}
+	to, err := excalidraw.Parse(revision.Elements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse revision elements: %w", err)
+	}
+
+	return &dto.GetBoardRevisionResponse{
+		Revision:   toBoardRevisionResponse(revision),
+		ComparedTo: comparedTo,
+		Diff:       excalidraw.DiffElements(from, to),
+	}, nil
+}
+
+func (s *boardRevisionService) RestoreRevision(ctx context.Context, req dto.RestoreBoardRevisionRequest) (*dto.GetBoardResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := authorizeBoard(ctx, qtx, boardID, req.UserID, RoleEditor); err != nil {
+		return nil, err
+	}
+
+	currentBoard, err := qtx.GetBoardByIDForUpdate(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+
+	revision, err := qtx.GetBoardRevision(ctx, repo.GetBoardRevisionParams{
+		BoardID:  currentBoard.ID,
+		Revision: req.Revision,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", dbError(err))
+	}
+
+	// The revision is held to the checks of any other write, since the
+	// limits may have been lowered since it was saved.
+	elements, err := excalidraw.ParseAndValidate(revision.Elements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate revision elements: %w", err)
+	}
+	if err := checkElementLimits(&s.config.Limits, elements); err != nil {
+		return nil, err
+	}
+
+	board, err := qtx.UpdateBoard(ctx, repo.UpdateBoardParams{
+		ID:       currentBoard.ID,
+		Name:     currentBoard.Name,
+		Elements: revision.Elements,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update board: %w", err)
+	}
+
+	if err := recordBoardRevision(ctx, qtx, &s.config.Board, board.ID, req.UserID, board.Elements, elements); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.thumbnails.Schedule(board.ID)
+
+	return &dto.GetBoardResponse{
+		Board: toBoardResponse(board),
+	}, nil
+}
+
+func (s *boardRevisionService) getBoard(ctx context.Context, id string, userID string) (repo.Board, error) {
+	boardID, err := parseBoardID(id)
+	if err != nil {
+		return repo.Board{}, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, userID, RoleViewer); err != nil {
+		return repo.Board{}, err
+	}
+	board, err := s.queries.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return repo.Board{}, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+	return board, nil
+}
+
+// comparisonBaseline resolves the elements a revision is diffed against.
+func (s *boardRevisionService) comparisonBaseline(ctx context.Context, board repo.Board, revision repo.BoardRevision, compare string) (string, json.RawMessage, error) {
+	switch compare {
+	case "", "previous":
+		previous, err := s.queries.GetPreviousBoardRevision(ctx, repo.GetPreviousBoardRevisionParams{
+			BoardID:  board.ID,
+			Revision: revision.Revision,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return "previous", nil, nil
+			}
+			return "", nil, fmt.Errorf("failed to get previous revision: %w", err)
+		}
+		return strconv.Itoa(int(previous.Revision)), previous.Elements, nil
+	case "current":
+		return "current", board.Elements, nil
+	default:
+		number, err := strconv.Atoi(compare)
+		if err != nil {
+			return "", nil, fmt.Errorf("compare %q: %w", compare, ErrInvalidInput)
+		}
+		other, err := s.queries.GetBoardRevision(ctx, repo.GetBoardRevisionParams{
+			BoardID:  board.ID,
+			Revision: int32(number),
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get revision %d: %w", number, dbError(err))
+		}
+		return compare, other.Elements, nil
+	}
+}
+
+// recordBoardRevision snapshots the board's elements and prunes revisions that
+// fall outside the configured retention. The revision is credited to userID,
+// or to no one if it is empty. It must run in the same transaction as the
+// board update it records.
+func recordBoardRevision(
+	ctx context.Context,
+	q *repo.Queries,
+	cfg *config.BoardConfig,
+	boardID uuid.UUID,
+	userID string,
+	raw json.RawMessage,
+	elements []excalidraw.Element,
+) error {
+	var createdBy *string
+	if userID != "" {
+		createdBy = &userID
+	}
+	if _, err := q.CreateBoardRevision(ctx, repo.CreateBoardRevisionParams{
+		BoardID:      boardID,
+		Elements:     raw,
+		ElementCount: int32(excalidraw.CountLive(elements)),
+		CreatedBy:    createdBy,
+	}); err != nil {
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+
+	var olderThan time.Time
+	if cfg.RevisionMaxAge > 0 {
+		olderThan = time.Now().Add(-cfg.RevisionMaxAge)
+	}
+	if err := q.PruneBoardRevisions(ctx, repo.PruneBoardRevisionsParams{
+		BoardID:   boardID,
+		Keep:      int32(cfg.RevisionLimit),
+		OlderThan: olderThan,
+	}); err != nil {
+		return fmt.Errorf("failed to prune revisions: %w", err)
+	}
+	return nil
+}
+
+func toBoardRevisionResponse(revision repo.BoardRevision) dto.BoardRevision {
+	return dto.BoardRevision{
+		ID:           revision.ID,
+		BoardID:      revision.BoardID,
+		Revision:     revision.Revision,
+		ElementCount: revision.ElementCount,
+		CreatedBy:    revision.CreatedBy,
+		CreatedAt:    revision.CreatedAt,
+		Elements:     revision.Elements,
+	}
+}
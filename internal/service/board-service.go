@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"draw/internal/db/repo"
 	"draw/internal/dto"
 	"draw/pkg/config"
+	"draw/pkg/excalidraw"
 	"draw/pkg/livekit"
 
 	"github.com/google/uuid"
@@ -15,38 +19,88 @@ import (
 
 type BoardService interface {
 	CreateBoard(ctx context.Context, req dto.CreateBoardRequest) (*dto.CreateBoardResponse, error)
+	DuplicateBoard(ctx context.Context, req dto.DuplicateBoardRequest) (*dto.CreateBoardResponse, error)
 	GetBoard(ctx context.Context, req dto.GetBoardRequest) (*dto.GetBoardResponse, error)
 	GetBoardsByUserID(ctx context.Context, req dto.GetBoardsByUserIDRequest) (*dto.GetBoardsByUserIDResponse, error)
 	UpdateBoard(ctx context.Context, req dto.UpdateBoardRequest) (*dto.GetBoardResponse, error)
+	PatchElements(ctx context.Context, req dto.PatchBoardElementsRequest) (*dto.PatchBoardElementsResponse, error)
+	DeleteBoard(ctx context.Context, req dto.DeleteBoardRequest) error
+	GetTrash(ctx context.Context, req dto.GetTrashRequest) (*dto.GetTrashResponse, error)
+	RestoreBoard(ctx context.Context, req dto.RestoreBoardRequest) (*dto.GetBoardResponse, error)
+	PurgeDeletedBoards(ctx context.Context) (int64, error)
 }
 
 type boardService struct {
 	queries *repo.Queries
 	db      *pgxpool.Pool
 	config  *config.AppConfig
+	thumbnails ThumbnailService
+	sessions *livekit.Manager
 }
 
 func NewBoardService(
 	db *pgxpool.Pool,
 	queries *repo.Queries,
+	thumbnails ThumbnailService,
+	sessions *livekit.Manager,
 	config *config.AppConfig,
 ) BoardService {
 	return &boardService{
 		db:      db,
 		queries: queries,
 		config: config,
+		thumbnails: thumbnails,
+		sessions: sessions,
 	}
 }
 
 
 func (s *boardService) CreateBoard(ctx context.Context, req dto.CreateBoardRequest) (*dto.CreateBoardResponse, error) {
-	board, err := s.queries.CreateBoard(ctx, repo.CreateBoardParams{
-		Name: req.Name,
-		OwnerID: req.UserID,
-	})
+	elements := req.Elements
+	sources := 0
+	for _, set := range []bool{req.Elements != nil, req.TemplateID != "", req.Mermaid != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, fmt.Errorf("elements, templateId and mermaid are mutually exclusive: %w", ErrInvalidInput)
+	}
+
+	if req.TemplateID != "" {
+		templateID, err := uuid.Parse(req.TemplateID)
+		if err != nil {
+			return nil, fmt.Errorf("template id %q: %w", req.TemplateID, ErrInvalidInput)
+		}
+		template, err := s.queries.GetBoardTemplate(ctx, repo.GetBoardTemplateParams{
+			ID: templateID,
+			UserID: req.UserID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get template: %w", dbError(err))
+		}
+		elements, err = cloneBoardElements(template.Elements)
+		if err != nil {
+			return nil, err
+		}
+	} else if req.Mermaid != "" {
+		imported, err := mermaidElements(req.Mermaid, nil)
+		if err != nil {
+			return nil, err
+		}
+		elements, err = excalidraw.Marshal(imported)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode elements: %w", err)
+		}
+	} else if req.Elements != nil {
+		if _, err := excalidraw.ParseAndValidate(req.Elements); err != nil {
+			return nil, fmt.Errorf("failed to validate elements: %w", err)
+		}
+	}
 
+	board, err := s.createBoard(ctx, req.Name, req.UserID, elements)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create board: %w", err)
+		return nil, err
 	}
 
 	return &dto.CreateBoardResponse{
@@ -55,64 +109,239 @@ func (s *boardService) CreateBoard(ctx context.Context, req dto.CreateBoardReque
 	}, nil
 }
 
-func (s *boardService) GetBoard(ctx context.Context, req dto.GetBoardRequest) (*dto.GetBoardResponse, error) {
-	board, err := s.queries.GetBoardByID(ctx, repo.GetBoardByIDParams{
-		ID:     uuid.MustParse(req.BoardID),
-		OwnerID: req.UserID,
-	})	
+// DuplicateBoard copies a board the user can see into a new board they own.
+// Elements get fresh ids so the copy can later be merged with the original.
+func (s *boardService) DuplicateBoard(ctx context.Context, req dto.DuplicateBoardRequest) (*dto.CreateBoardResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	source, err := s.queries.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+
+	elements, err := cloneBoardElements(source.Elements)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get board: %w", err)	
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = "Copy of " + source.Name
+	}
+
+	board, err := s.createBoard(ctx, name, req.UserID, elements)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CreateBoardResponse{
+		BoardID: board.ID,
+	}, nil
+}
+
+// createBoard inserts a board and its owner membership, provided the owner
+// is within their board quota and the elements within the size limits.
+func (s *boardService) createBoard(ctx context.Context, name string, ownerID string, elements json.RawMessage) (repo.Board, error) {
+	parsed, err := excalidraw.Parse(elements)
+	if err != nil {
+		return repo.Board{}, fmt.Errorf("failed to parse elements: %w", err)
+	}
+	if err := checkElementLimits(&s.config.Limits, parsed); err != nil {
+		return repo.Board{}, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return repo.Board{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if err := checkBoardQuota(ctx, qtx, &s.config.Limits, ownerID); err != nil {
+		return repo.Board{}, err
+	}
+
+	board, err := qtx.CreateBoard(ctx, repo.CreateBoardParams{
+		Name: name,
+		OwnerID: ownerID,
+		Elements: elements,
+	})
+	if err != nil {
+		return repo.Board{}, fmt.Errorf("failed to create board: %w", err)
+	}
+
+	if _, err := qtx.CreateBoardMember(ctx, repo.CreateBoardMemberParams{
+		BoardID: board.ID,
+		UserID: ownerID,
+		Role: RoleOwner,
+	}); err != nil {
+		return repo.Board{}, fmt.Errorf("failed to add board owner: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return repo.Board{}, fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	s.thumbnails.Schedule(board.ID)
+	return board, nil
+}
 
-	userDetails, err := s.queries.GetUserByID(ctx, req.UserID)
+// cloneBoardElements copies stored elements with fresh element ids.
+func cloneBoardElements(raw json.RawMessage) (json.RawMessage, error) {
+	elements, err := excalidraw.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse elements: %w", err)
+	}
+	if elements == nil {
+		return nil, nil
+	}
+	cloned, err := excalidraw.Marshal(excalidraw.CloneElements(elements, excalidraw.NewID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, fmt.Errorf("failed to marshal elements: %w", err)
 	}
+	return cloned, nil
+}
 
-	session, err := livekit.NewLiveKitSession(
-		&userDetails,
-		board.ID.String(),
-		s.config,
-		livekit.SessionCallbacks{},
-	)
+func (s *boardService) GetBoard(ctx context.Context, req dto.GetBoardRequest) (*dto.GetBoardResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := session.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start session: %w", err)
+	role, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer)
+	if err != nil {
+		return nil, err
 	}
-	
-	token, err := session.GenerateUserToken()
+
+	board, err := s.queries.GetBoardByID(ctx, boardID)
 	if err != nil {
-		session.Stop()
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, fmt.Errorf("failed to get board: %w", dbError(err))
 	}
 
+	boardResponse := toBoardResponse(board)
+	boardResponse.Role = role
 	return &dto.GetBoardResponse{
-		Board: toBoardResponse(board),
-		Token: token,
+		Board: boardResponse,
 	}, nil
 }
 
 func (s *boardService) GetBoardsByUserID(ctx context.Context, req dto.GetBoardsByUserIDRequest) (*dto.GetBoardsByUserIDResponse, error) {
-	boards, err := s.queries.GetBoardsByUserID(ctx, req.UserID)
+	params := repo.ListBoardsByUserIDParams{
+		UserID: req.UserID,
+		SortBy: req.Sort,
+		PageSize: req.Limit,
+	}
+	if params.SortBy == "" {
+		params.SortBy = SortUpdatedAt
+	}
+	// Names read naturally A-Z; timestamps newest first.
+	params.Descending = params.SortBy != SortName
+	if req.Order != "" {
+		params.Descending = req.Order == "desc"
+	}
+	if params.PageSize <= 0 || params.PageSize > maxBoardPageSize {
+		params.PageSize = defaultBoardPageSize
+	}
+	if search := strings.TrimSpace(req.Search); search != "" {
+		pattern := likePattern(search)
+		params.Search = &pattern
+	}
+	if req.Folder != "" {
+		folderID, err := uuid.Parse(req.Folder)
+		if err != nil {
+			return nil, fmt.Errorf("folder id %q: %w", req.Folder, ErrInvalidInput)
+		}
+		params.FolderID = &folderID
+	}
+	if tag := normalizeTag(req.Tag); tag != "" {
+		params.Tag = &tag
+	}
+	if req.Cursor != "" {
+		if err := applyBoardCursor(&params, req.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Fetch one extra row to learn whether there is a next page.
+	pageSize := params.PageSize
+	params.PageSize++
+	rows, err := s.queries.ListBoardsByUserID(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get boards: %w", err)
 	}
-	boardsResponse := make([]dto.Board, 0, len(boards))
-	for _, board := range boards {
-		boardsResponse = append(boardsResponse, toBoardResponse(board))
+
+	resp := &dto.GetBoardsByUserIDResponse{}
+	if len(rows) > int(pageSize) {
+		rows = rows[:pageSize]
+		resp.NextCursor = encodeBoardCursor(params.SortBy, params.Descending, rows[len(rows)-1])
 	}
-	return &dto.GetBoardsByUserIDResponse{
-		Boards: boardsResponse,
-	}, nil
+	now := time.Now()
+	resp.Boards = make([]dto.BoardSummary, 0, len(rows))
+	for _, row := range rows {
+		summary := dto.BoardSummary{
+			ID: row.ID,
+			Name: row.Name,
+			OwnerID: row.OwnerID,
+			Role: row.Role,
+			Version: row.Version,
+			ElementCount: row.ElementCount,
+			FolderID: row.FolderID,
+			Tags: row.Tags,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		}
+		if row.ThumbnailVersion != nil {
+			summary.ThumbnailURL = boardThumbnailURL(&s.config.Storage, row.ID, *row.ThumbnailVersion, now)
+		}
+		resp.Boards = append(resp.Boards, summary)
+	}
+	return resp, nil
 }
 
 func (s *boardService) UpdateBoard(ctx context.Context, req dto.UpdateBoardRequest) (*dto.GetBoardResponse, error) {
-	currentBoard, err := s.queries.GetBoardByID(ctx, repo.GetBoardByIDParams{
-		ID: uuid.MustParse(req.BoardID),
-		OwnerID: req.UserID,
-	})
+	boardID, err := parseBoardID(req.BoardID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get board: %w", err)
+		return nil, err
+	}
+
+	var elements []excalidraw.Element
+	if req.Elements != nil {
+		elements, err = excalidraw.ParseAndValidate(req.Elements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate elements: %w", err)
+		}
+		if err := checkElementLimits(&s.config.Limits, elements); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := authorizeBoard(ctx, qtx, boardID, req.UserID, RoleEditor); err != nil {
+		return nil, err
+	}
+
+	currentBoard, err := qtx.GetBoardByIDForUpdate(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+
+	if req.IfMatch != nil && *req.IfMatch != currentBoard.Version {
+		return nil, &BoardConflictError{
+			Version: currentBoard.Version,
+			Elements: currentBoard.Elements,
+		}
 	}
 
 	if req.Name != "" {
@@ -122,26 +351,196 @@ func (s *boardService) UpdateBoard(ctx context.Context, req dto.UpdateBoardReque
 		currentBoard.Elements = req.Elements
 	}
 
-	board, err := s.queries.UpdateBoard(ctx, repo.UpdateBoardParams{
+	board, err := qtx.UpdateBoard(ctx, repo.UpdateBoardParams{
 		ID: currentBoard.ID,
 		Name: currentBoard.Name,
 		Elements: currentBoard.Elements,
-		OwnerID: req.UserID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update board: %w", err)
 	}
 
+	if req.Elements != nil {
+		if err := recordBoardRevision(ctx, qtx, &s.config.Board, board.ID, req.UserID, board.Elements, elements); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if req.Elements != nil {
+		s.thumbnails.Schedule(board.ID)
+	}
+
 	return &dto.GetBoardResponse{
 		Board: toBoardResponse(board),
 	}, nil
 }
 
+func (s *boardService) PatchElements(ctx context.Context, req dto.PatchBoardElementsRequest) (*dto.PatchBoardElementsResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := authorizeBoard(ctx, qtx, boardID, req.UserID, RoleEditor); err != nil {
+		return nil, err
+	}
+
+	currentBoard, err := qtx.GetBoardByIDForUpdate(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+
+	current, err := excalidraw.Parse(currentBoard.Elements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stored elements: %w", err)
+	}
+
+	result := excalidraw.ApplyOperations(current, req.Operations)
+	resp := &dto.PatchBoardElementsResponse{
+		Applied: result.Applied,
+		Rejected: result.Rejected,
+	}
+	if len(result.Applied) == 0 {
+		return resp, nil
+	}
+
+	if err := excalidraw.Validate(result.Elements); err != nil {
+		return nil, fmt.Errorf("failed to validate elements: %w", err)
+	}
+	if err := checkElementLimits(&s.config.Limits, result.Elements); err != nil {
+		return nil, err
+	}
+	elements, err := excalidraw.Marshal(result.Elements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode elements: %w", err)
+	}
+
+	board, err := qtx.UpdateBoard(ctx, repo.UpdateBoardParams{
+		ID: currentBoard.ID,
+		Name: currentBoard.Name,
+		Elements: elements,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update board: %w", err)
+	}
+
+	if err := recordBoardRevision(ctx, qtx, &s.config.Board, board.ID, req.UserID, board.Elements, result.Elements); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.thumbnails.Schedule(board.ID)
+
+	return resp, nil
+}
+
+// DeleteBoard moves a board to its owner's trash. Trashed boards are hidden
+// from every member until restored or purged.
+func (s *boardService) DeleteBoard(ctx context.Context, req dto.DeleteBoardRequest) error {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleOwner); err != nil {
+		return err
+	}
+
+	// The live session is ended first, so that the edits it has yet to save
+	// are kept with the board in the trash rather than failing against it.
+	s.sessions.End(boardID.String())
+
+	deleted, err := s.queries.SoftDeleteBoard(ctx, boardID)
+	if err != nil {
+		return fmt.Errorf("failed to delete board: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("failed to delete board: %w", ErrNotFound)
+	}
+	return nil
+}
+
+func (s *boardService) GetTrash(ctx context.Context, req dto.GetTrashRequest) (*dto.GetTrashResponse, error) {
+	boards, err := s.queries.GetDeletedBoardsByOwnerID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trash: %w", err)
+	}
+	boardsResponse := make([]dto.Board, 0, len(boards))
+	for _, board := range boards {
+		boardResponse := toBoardResponse(board)
+		boardResponse.Role = RoleOwner
+		boardsResponse = append(boardsResponse, boardResponse)
+	}
+	return &dto.GetTrashResponse{
+		Boards: boardsResponse,
+	}, nil
+}
+
+func (s *boardService) RestoreBoard(ctx context.Context, req dto.RestoreBoardRequest) (*dto.GetBoardResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if err := checkBoardQuota(ctx, qtx, &s.config.Limits, req.UserID); err != nil {
+		return nil, err
+	}
+
+	board, err := qtx.RestoreBoard(ctx, repo.RestoreBoardParams{
+		ID: boardID,
+		OwnerID: req.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore board: %w", dbError(err))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	boardResponse := toBoardResponse(board)
+	boardResponse.Role = RoleOwner
+	return &dto.GetBoardResponse{
+		Board: boardResponse,
+	}, nil
+}
+
+// PurgeDeletedBoards permanently deletes boards that have been in the trash
+// longer than the configured retention.
+func (s *boardService) PurgeDeletedBoards(ctx context.Context) (int64, error) {
+	purged, err := s.queries.PurgeDeletedBoards(ctx, time.Now().Add(-s.config.Board.TrashRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted boards: %w", err)
+	}
+	return purged, nil
+}
+
 func toBoardResponse(board repo.Board) dto.Board {
 	return dto.Board{
 		ID: board.ID,
 		Name: board.Name,
 		OwnerID: board.OwnerID,
 		Elements: board.Elements,
+		Version: board.Version,
+		DeletedAt: board.DeletedAt,
 	}
 }
\ No newline at end of file
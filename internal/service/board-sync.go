@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"draw/internal/db/repo"
+	"draw/pkg/config"
+	"draw/pkg/excalidraw"
+	"draw/pkg/livekit"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// boardSyncCallbacks lets a live session read the board it serves and write
+// back the edits it merged. Participant identities are user ids, so each save
+// is attributed to the participant who made its latest edit.
+func boardSyncCallbacks(db *pgxpool.Pool, queries *repo.Queries, thumbnails ThumbnailService, cfg *config.AppConfig) livekit.SessionCallbacks {
+	return livekit.SessionCallbacks{
+		GetBoardState: func(boardID string) (json.RawMessage, error) {
+			id, err := parseBoardID(boardID)
+			if err != nil {
+				return nil, err
+			}
+			board, err := queries.GetBoardByID(context.Background(), id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+			}
+			return board.Elements, nil
+		},
+		SaveBoardState: func(boardID string, identity string, elements json.RawMessage) error {
+			id, err := parseBoardID(boardID)
+			if err != nil {
+				return err
+			}
+			if err := saveSyncedElements(context.Background(), db, queries, cfg, id, identity, elements); err != nil {
+				return err
+			}
+			thumbnails.Schedule(id)
+			return nil
+		},
+		GetAccess: func(boardID string, identity string) (livekit.Access, error) {
+			id, err := parseBoardID(boardID)
+			if err != nil {
+				return livekit.AccessNone, err
+			}
+			role, err := queries.GetBoardMemberRole(context.Background(), repo.GetBoardMemberRoleParams{
+				BoardID: id,
+				UserID:  identity,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				return livekit.AccessNone, nil
+			}
+			if err != nil {
+				return livekit.AccessNone, fmt.Errorf("failed to get member role: %w", err)
+			}
+			return sessionAccess(role), nil
+		},
+	}
+}
+
+// sessionAccess is what a member with role may do in the board's session.
+func sessionAccess(role string) livekit.Access {
+	if roleRank[role] >= roleRank[RoleEditor] {
+		return livekit.AccessEdit
+	}
+	return livekit.AccessView
+}
+
+// saveSyncedElements stores the elements a live session changed since its
+// last save. They are reconciled into the board as stored, so that edits
+// saved through the API since then win where they are newer, and the result
+// is held to the same checks as an API write. The revision it records is
+// credited to userID.
+func saveSyncedElements(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	cfg *config.AppConfig,
+	boardID uuid.UUID,
+	userID string,
+	raw json.RawMessage,
+) error {
+	synced, err := excalidraw.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse elements: %w", err)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := queries.WithTx(tx)
+
+	current, err := qtx.GetBoardByIDForUpdate(ctx, boardID)
+	if err != nil {
+		return fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+	stored, err := excalidraw.Parse(current.Elements)
+	if err != nil {
+		return fmt.Errorf("failed to parse stored elements: %w", err)
+	}
+	merged, accepted := excalidraw.Reconcile(stored, synced)
+	if len(accepted) == 0 {
+		return nil
+	}
+	if err := excalidraw.Validate(merged); err != nil {
+		return fmt.Errorf("failed to validate elements: %w", err)
+	}
+	if err := checkElementLimits(&cfg.Limits, merged); err != nil {
+		return err
+	}
+	elements, err := excalidraw.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("failed to encode elements: %w", err)
+	}
+
+	board, err := qtx.UpdateBoard(ctx, repo.UpdateBoardParams{
+		ID:       current.ID,
+		Name:     current.Name,
+		Elements: elements,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update board: %w", err)
+	}
+	if err := recordBoardRevision(ctx, qtx, &cfg.Board, board.ID, userID, board.Elements, merged); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
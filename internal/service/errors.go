@@ -0,0 +1,59 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	// ErrNotFound is returned when a resource does not exist or is not
+	// visible to the caller.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidInput is returned when a request parameter is malformed.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrForbidden is returned when the caller may see a resource but not
+	// perform the requested action on it.
+	ErrForbidden = errors.New("forbidden")
+	// ErrConflict is returned when a write was based on stale state.
+	ErrConflict = errors.New("conflict")
+	// ErrTooLarge is returned when an upload exceeds the configured size.
+	ErrTooLarge = errors.New("too large")
+	// ErrQuotaExceeded is returned when the caller already has as much of a
+	// resource as they are allowed.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+// BoardConflictError is returned when an update names a board version that is
+// no longer current. It carries the current state so the client can rebase.
+type BoardConflictError struct {
+	Version  int64
+	Elements json.RawMessage
+}
+
+func (e *BoardConflictError) Error() string {
+	return fmt.Sprintf("board has been modified, current version is %d", e.Version)
+}
+
+func (e *BoardConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// dbError translates driver errors into service errors.
+func dbError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func parseBoardID(id string) (uuid.UUID, error) {
+	boardID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("board id %q: %w", id, ErrInvalidInput)
+	}
+	return boardID, nil
+}
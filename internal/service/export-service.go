@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+	"draw/pkg/excalidraw"
+	"draw/pkg/export"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExportService interface {
+	ExportSVG(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error)
+	ExportPNG(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error)
+	ExportPDF(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error)
+	ExportMermaid(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error)
+	ExportDOT(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error)
+}
+
+type exportService struct {
+	queries *repo.Queries
+	db      *pgxpool.Pool
+	config  *config.AppConfig
+}
+
+func NewExportService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	config *config.AppConfig,
+) ExportService {
+	return &exportService{
+		db:      db,
+		queries: queries,
+		config:  config,
+	}
+}
+
+func (s *exportService) ExportSVG(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error) {
+	board, elements, err := s.loadBoard(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := export.SVG(elements, exportOptions(req))
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	return &dto.ExportBoardResponse{
+		FileName:    exportFileName(board.Name, "svg"),
+		ContentType: "image/svg+xml",
+		Content:     content,
+	}, nil
+}
+
+func (s *exportService) ExportPNG(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error) {
+	board, elements, err := s.loadBoard(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := export.PNG(elements, exportOptions(req))
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	return &dto.ExportBoardResponse{
+		FileName:    exportFileName(board.Name, "png"),
+		ContentType: "image/png",
+		Content:     content,
+	}, nil
+}
+
+// ExportPDF renders one page per frame, or the whole board on a single page
+// when it has no frames.
+func (s *exportService) ExportPDF(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error) {
+	board, elements, err := s.loadBoard(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := export.PDF(elements, exportOptions(req))
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	return &dto.ExportBoardResponse{
+		FileName:    exportFileName(board.Name, "pdf"),
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// ExportMermaid writes the board's shapes and arrows as a Mermaid flowchart.
+func (s *exportService) ExportMermaid(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error) {
+	board, elements, err := s.loadBoard(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, skipped := export.Mermaid(elements)
+	return &dto.ExportBoardResponse{
+		FileName:    exportFileName(board.Name, "mmd"),
+		ContentType: "text/plain; charset=utf-8",
+		Content:     content,
+		Skipped:     len(skipped),
+	}, nil
+}
+
+// ExportDOT writes the board's shapes and arrows as a Graphviz digraph.
+func (s *exportService) ExportDOT(ctx context.Context, req dto.ExportBoardRequest) (*dto.ExportBoardResponse, error) {
+	board, elements, err := s.loadBoard(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, skipped := export.DOT(elements)
+	return &dto.ExportBoardResponse{
+		FileName:    exportFileName(board.Name, "dot"),
+		ContentType: "text/vnd.graphviz; charset=utf-8",
+		Content:     content,
+		Skipped:     len(skipped),
+	}, nil
+}
+
+func (s *exportService) loadBoard(ctx context.Context, req dto.ExportBoardRequest) (repo.Board, []excalidraw.Element, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return repo.Board{}, nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return repo.Board{}, nil, err
+	}
+	board, err := s.queries.GetBoardByID(ctx, boardID)
+	if err != nil {
+		return repo.Board{}, nil, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+	elements, err := excalidraw.Parse(board.Elements)
+	if err != nil {
+		return repo.Board{}, nil, fmt.Errorf("failed to parse elements: %w", err)
+	}
+	return board, elements, nil
+}
+
+func exportOptions(req dto.ExportBoardRequest) export.Options {
+	opts := export.DefaultOptions()
+	if req.Background != nil {
+		opts.Background = *req.Background
+	}
+	if req.BackgroundColor != "" {
+		opts.BackgroundColor = req.BackgroundColor
+	}
+	if req.Padding != nil {
+		opts.Padding = *req.Padding
+	}
+	opts.DarkMode = req.DarkMode
+	opts.FrameID = req.FrameID
+	if req.Scale != nil {
+		opts.Scale = *req.Scale
+	}
+	return opts
+}
+
+func exportError(err error) error {
+	if errors.Is(err, export.ErrFrameNotFound) {
+		return fmt.Errorf("%v: %w", err, ErrNotFound)
+	}
+	if errors.Is(err, export.ErrTooLarge) {
+		return fmt.Errorf("%v: %w", err, ErrInvalidInput)
+	}
+	return fmt.Errorf("failed to export board: %w", err)
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportFileName derives a download name from the board name.
+func exportFileName(boardName string, ext string) string {
+	name := strings.Trim(unsafeFileNameChars.ReplaceAllString(boardName, "-"), "-.")
+	if name == "" {
+		name = "board"
+	}
+	return name + "." + ext
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+	"draw/pkg/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// fileGCBatch is how many unreferenced files one collection pass deletes
+// before checking for more.
+const fileGCBatch = 100
+
+// fileIDPattern matches the ids Excalidraw generates for files: a content
+// hash, or a nanoid for older clients.
+var fileIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// boardFileTypes are the image formats Excalidraw can place on a board.
+var boardFileTypes = map[string]bool{
+	"image/png":     true,
+	"image/jpeg":    true,
+	"image/gif":     true,
+	"image/webp":    true,
+	"image/bmp":     true,
+	"image/x-icon":  true,
+	"image/svg+xml": true,
+}
+
+type FileService interface {
+	UploadFile(ctx context.Context, req dto.UploadBoardFileRequest) (*dto.BoardFile, error)
+	GetFile(ctx context.Context, req dto.GetBoardFileRequest) (*dto.BoardFileContent, error)
+	ListFiles(ctx context.Context, req dto.ListBoardFilesRequest) (*dto.ListBoardFilesResponse, error)
+	// CollectUnreferencedFiles deletes files that no element or revision
+	// refers to any more and returns how many were removed.
+	CollectUnreferencedFiles(ctx context.Context) (int64, error)
+}
+
+type fileService struct {
+	queries *repo.Queries
+	db      *pgxpool.Pool
+	storage storage.Storage
+	config  *config.AppConfig
+}
+
+func NewFileService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	storage storage.Storage,
+	config *config.AppConfig,
+) FileService {
+	return &fileService{
+		db:      db,
+		queries: queries,
+		storage: storage,
+		config:  config,
+	}
+}
+
+func (s *fileService) UploadFile(ctx context.Context, req dto.UploadBoardFileRequest) (*dto.BoardFile, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if !fileIDPattern.MatchString(req.FileID) {
+		return nil, fmt.Errorf("file id %q: %w", req.FileID, ErrInvalidInput)
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleEditor); err != nil {
+		return nil, err
+	}
+
+	maxSize := s.config.Storage.MaxFileSize
+	data, err := io.ReadAll(io.LimitReader(req.Body, maxSize+1))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, fmt.Errorf("file is larger than %d bytes: %w", tooLarge.Limit, ErrTooLarge)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file is larger than %d bytes: %w", maxSize, ErrTooLarge)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("file is empty: %w", ErrInvalidInput)
+	}
+	// The declared content type is ignored; what is served back is decided
+	// by the bytes alone.
+	mimeType := sniffFileType(data)
+	if !boardFileTypes[mimeType] {
+		return nil, fmt.Errorf("unsupported file type %q: %w", mimeType, ErrInvalidInput)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	// Files are served as immutable, so a file id keeps the bytes it was
+	// first uploaded with. Uploading them again is harmless; anything else
+	// is refused.
+	existing, err := s.queries.GetBoardFile(ctx, repo.GetBoardFileParams{
+		BoardID: boardID,
+		FileID:  req.FileID,
+	})
+	if err == nil {
+		return s.reupload(ctx, existing, hash)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	// The key is unique to the content, so that a concurrent upload of
+	// different bytes under the same id cannot overwrite this one.
+	key := boardFileKey(boardID, req.FileID, hash)
+	if err := s.storage.Put(ctx, key, data, mimeType); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	file, err := s.queries.CreateBoardFile(ctx, repo.CreateBoardFileParams{
+		BoardID:     boardID,
+		FileID:      req.FileID,
+		MimeType:    mimeType,
+		Size:        int64(len(data)),
+		StorageKey:  key,
+		CreatedBy:   &req.UserID,
+		ContentHash: &hash,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Another upload recorded the id first.
+		existing, err := s.queries.GetBoardFile(ctx, repo.GetBoardFileParams{
+			BoardID: boardID,
+			FileID:  req.FileID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file: %w", dbError(err))
+		}
+		if existing.StorageKey != key {
+			if err := s.storage.Delete(ctx, key); err != nil {
+				return nil, fmt.Errorf("failed to delete duplicate file: %w", err)
+			}
+		}
+		return s.reupload(ctx, existing, hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record file: %w", err)
+	}
+	return toBoardFileDTO(file), nil
+}
+
+// reupload answers an upload of hash under an id that is already taken.
+func (s *fileService) reupload(ctx context.Context, existing repo.BoardFile, hash string) (*dto.BoardFile, error) {
+	storedHash, err := s.contentHash(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	if storedHash != hash {
+		return nil, fmt.Errorf("file %s already exists with different content: %w", existing.FileID, ErrConflict)
+	}
+	return toBoardFileDTO(existing), nil
+}
+
+// contentHash returns the hash of a stored file, reading it back for files
+// recorded before hashes were kept.
+func (s *fileService) contentHash(ctx context.Context, file repo.BoardFile) (string, error) {
+	if file.ContentHash != nil {
+		return *file.ContentHash, nil
+	}
+	body, err := s.storage.Get(ctx, file.StorageKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	defer body.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (s *fileService) GetFile(ctx context.Context, req dto.GetBoardFileRequest) (*dto.BoardFileContent, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	file, err := s.queries.GetBoardFile(ctx, repo.GetBoardFileParams{
+		BoardID: boardID,
+		FileID:  req.FileID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", dbError(err))
+	}
+	body, err := s.storage.Get(ctx, file.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("file %s is missing from storage: %w", file.FileID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return &dto.BoardFileContent{
+		Body:     body,
+		MimeType: file.MimeType,
+		Size:     file.Size,
+	}, nil
+}
+
+func (s *fileService) ListFiles(ctx context.Context, req dto.ListBoardFilesRequest) (*dto.ListBoardFilesResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListBoardFiles(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	files := make([]dto.BoardFile, 0, len(rows))
+	for _, row := range rows {
+		files = append(files, *toBoardFileDTO(row))
+	}
+	return &dto.ListBoardFilesResponse{
+		Files: files,
+	}, nil
+}
+
+func (s *fileService) CollectUnreferencedFiles(ctx context.Context) (int64, error) {
+	createdBefore := time.Now().Add(-s.config.Storage.FileGCGrace)
+	var collected int64
+	for {
+		files, err := s.queries.ListUnreferencedBoardFiles(ctx, repo.ListUnreferencedBoardFilesParams{
+			CreatedBefore: createdBefore,
+			PageSize:      fileGCBatch,
+		})
+		if err != nil {
+			return collected, fmt.Errorf("failed to list unreferenced files: %w", err)
+		}
+		for _, file := range files {
+			// Delete the object first: a row without an object is retried on
+			// the next pass, an object without a row would leak.
+			if err := s.storage.Delete(ctx, file.StorageKey); err != nil {
+				return collected, fmt.Errorf("failed to delete file %s: %w", file.StorageKey, err)
+			}
+			if _, err := s.queries.DeleteBoardFile(ctx, repo.DeleteBoardFileParams{
+				BoardID: file.BoardID,
+				FileID:  file.FileID,
+			}); err != nil {
+				return collected, fmt.Errorf("failed to delete file record: %w", err)
+			}
+			collected++
+		}
+		if len(files) < fileGCBatch {
+			return collected, nil
+		}
+	}
+}
+
+func boardFileKey(boardID uuid.UUID, fileID string, hash string) string {
+	return fmt.Sprintf("boards/%s/files/%s/%s", boardID, fileID, hash)
+}
+
+// sniffFileType detects the MIME type of data. SVG is text to the standard
+// sniffer, so it is recognised separately.
+func sniffFileType(data []byte) string {
+	mimeType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	if mimeType == "text/xml" || mimeType == "text/plain" {
+		head := bytes.ToLower(data[:min(len(data), 1024)])
+		if bytes.Contains(head, []byte("<svg")) {
+			return "image/svg+xml"
+		}
+	}
+	return mimeType
+}
+
+func toBoardFileDTO(file repo.BoardFile) *dto.BoardFile {
+	return &dto.BoardFile{
+		ID:        file.FileID,
+		MimeType:  file.MimeType,
+		Size:      file.Size,
+		CreatedAt: file.CreatedAt,
+	}
+}
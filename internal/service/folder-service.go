@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FolderService manages a user's private folder tree. Folders only organise
+// the user's own view of their boards; sharing is unaffected.
+type FolderService interface {
+	ListFolders(ctx context.Context, req dto.ListFoldersRequest) (*dto.ListFoldersResponse, error)
+	CreateFolder(ctx context.Context, req dto.CreateFolderRequest) (*dto.Folder, error)
+	UpdateFolder(ctx context.Context, req dto.UpdateFolderRequest) (*dto.Folder, error)
+	DeleteFolder(ctx context.Context, req dto.DeleteFolderRequest) error
+	MoveBoard(ctx context.Context, req dto.MoveBoardRequest) (*dto.MoveBoardResponse, error)
+}
+
+type folderService struct {
+	queries *repo.Queries
+	db      *pgxpool.Pool
+	config  *config.AppConfig
+}
+
+func NewFolderService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	config *config.AppConfig,
+) FolderService {
+	return &folderService{
+		db:      db,
+		queries: queries,
+		config:  config,
+	}
+}
+
+func (s *folderService) ListFolders(ctx context.Context, req dto.ListFoldersRequest) (*dto.ListFoldersResponse, error) {
+	rows, err := s.queries.ListFolders(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list folders: %w", err)
+	}
+
+	folders := make([]dto.Folder, 0, len(rows))
+	for _, row := range rows {
+		folders = append(folders, dto.Folder{
+			ID:         row.ID,
+			ParentID:   row.ParentID,
+			Name:       row.Name,
+			BoardCount: row.BoardCount,
+			CreatedAt:  row.CreatedAt,
+			UpdatedAt:  row.UpdatedAt,
+		})
+	}
+	return &dto.ListFoldersResponse{
+		Folders: folders,
+	}, nil
+}
+
+func (s *folderService) CreateFolder(ctx context.Context, req dto.CreateFolderRequest) (*dto.Folder, error) {
+	name, err := folderName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := parseFolderID(req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		if _, err := s.queries.GetFolder(ctx, repo.GetFolderParams{ID: *parentID, UserID: req.UserID}); err != nil {
+			return nil, fmt.Errorf("failed to get parent folder: %w", dbError(err))
+		}
+	}
+
+	folder, err := s.queries.CreateFolder(ctx, repo.CreateFolderParams{
+		UserID:   req.UserID,
+		ParentID: parentID,
+		Name:     name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create folder: %w", err)
+	}
+	return toFolderDTO(folder), nil
+}
+
+func (s *folderService) UpdateFolder(ctx context.Context, req dto.UpdateFolderRequest) (*dto.Folder, error) {
+	folderID, err := parseFolderID(req.FolderID)
+	if err != nil || folderID == nil {
+		return nil, fmt.Errorf("folder id %q: %w", req.FolderID, ErrInvalidInput)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if req.ParentID != nil {
+		// Two moves checked side by side could each pass the check below
+		// and together form a loop, so moves of one user's folders take
+		// turns.
+		if err := qtx.LockUserFolders(ctx, req.UserID); err != nil {
+			return nil, fmt.Errorf("failed to lock folders: %w", err)
+		}
+	}
+
+	folder, err := qtx.GetFolder(ctx, repo.GetFolderParams{ID: *folderID, UserID: req.UserID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folder: %w", dbError(err))
+	}
+
+	params := repo.UpdateFolderParams{
+		ID:       folder.ID,
+		UserID:   req.UserID,
+		Name:     folder.Name,
+		ParentID: folder.ParentID,
+	}
+	if req.Name != nil {
+		if params.Name, err = folderName(*req.Name); err != nil {
+			return nil, err
+		}
+	}
+	if req.ParentID != nil {
+		if params.ParentID, err = parseFolderID(*req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	if params.ParentID != nil {
+		if _, err := qtx.GetFolder(ctx, repo.GetFolderParams{ID: *params.ParentID, UserID: req.UserID}); err != nil {
+			return nil, fmt.Errorf("failed to get parent folder: %w", dbError(err))
+		}
+		// Moving a folder under itself or one of its descendants would cut
+		// the subtree off from the root.
+		ancestors, err := qtx.ListFolderAncestorIDs(ctx, *params.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent folders: %w", err)
+		}
+		for _, id := range ancestors {
+			if id == folder.ID {
+				return nil, fmt.Errorf("cannot move a folder into itself: %w", ErrInvalidInput)
+			}
+		}
+	}
+
+	folder, err = qtx.UpdateFolder(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update folder: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return toFolderDTO(folder), nil
+}
+
+// DeleteFolder removes the folder and its subfolders. Boards filed in them
+// are not deleted; they return to the top level.
+func (s *folderService) DeleteFolder(ctx context.Context, req dto.DeleteFolderRequest) error {
+	folderID, err := parseFolderID(req.FolderID)
+	if err != nil || folderID == nil {
+		return fmt.Errorf("folder id %q: %w", req.FolderID, ErrInvalidInput)
+	}
+	n, err := s.queries.DeleteFolder(ctx, repo.DeleteFolderParams{ID: *folderID, UserID: req.UserID})
+	if err != nil {
+		return fmt.Errorf("failed to delete folder: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("folder %s: %w", folderID, ErrNotFound)
+	}
+	return nil
+}
+
+func (s *folderService) MoveBoard(ctx context.Context, req dto.MoveBoardRequest) (*dto.MoveBoardResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseFolderID(req.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+	if folderID != nil {
+		if _, err := s.queries.GetFolder(ctx, repo.GetFolderParams{ID: *folderID, UserID: req.UserID}); err != nil {
+			return nil, fmt.Errorf("failed to get folder: %w", dbError(err))
+		}
+	}
+
+	n, err := s.queries.SetBoardMemberFolder(ctx, repo.SetBoardMemberFolderParams{
+		BoardID:  boardID,
+		UserID:   req.UserID,
+		FolderID: folderID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move board: %w", err)
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("board %s: %w", boardID, ErrNotFound)
+	}
+	return &dto.MoveBoardResponse{
+		BoardID:  boardID,
+		FolderID: folderID,
+	}, nil
+}
+
+// parseFolderID parses an optional folder id; the empty string is the top
+// level.
+func parseFolderID(id string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+	folderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("folder id %q: %w", id, ErrInvalidInput)
+	}
+	return &folderID, nil
+}
+
+func folderName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("folder name is required: %w", ErrInvalidInput)
+	}
+	return name, nil
+}
+
+func toFolderDTO(folder repo.Folder) *dto.Folder {
+	return &dto.Folder{
+		ID:        folder.ID,
+		ParentID:  folder.ParentID,
+		Name:      folder.Name,
+		CreatedAt: folder.CreatedAt,
+		UpdatedAt: folder.UpdatedAt,
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+	"draw/pkg/excalidraw"
+	"draw/pkg/mermaid"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// importGap is the space left between existing content and imported
+// elements.
+const importGap = 100
+
+type ImportService interface {
+	ImportMermaid(ctx context.Context, req dto.ImportMermaidRequest) (*dto.ImportMermaidResponse, error)
+}
+
+type importService struct {
+	queries    *repo.Queries
+	db         *pgxpool.Pool
+	thumbnails ThumbnailService
+	config     *config.AppConfig
+}
+
+func NewImportService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	thumbnails ThumbnailService,
+	config *config.AppConfig,
+) ImportService {
+	return &importService{
+		db:         db,
+		queries:    queries,
+		thumbnails: thumbnails,
+		config:     config,
+	}
+}
+
+// ImportMermaid converts a Mermaid diagram and adds it to the board to the
+// right of the existing content.
+func (s *importService) ImportMermaid(ctx context.Context, req dto.ImportMermaidRequest) (*dto.ImportMermaidResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := authorizeBoard(ctx, qtx, boardID, req.UserID, RoleEditor); err != nil {
+		return nil, err
+	}
+
+	currentBoard, err := qtx.GetBoardByIDForUpdate(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+
+	current, err := excalidraw.Parse(currentBoard.Elements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stored elements: %w", err)
+	}
+
+	imported, err := mermaidElements(req.Source, current)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := append(current, imported...)
+	if err := excalidraw.Validate(merged); err != nil {
+		return nil, fmt.Errorf("failed to validate elements: %w", err)
+	}
+	if err := checkElementLimits(&s.config.Limits, merged); err != nil {
+		return nil, err
+	}
+	elements, err := excalidraw.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode elements: %w", err)
+	}
+
+	board, err := qtx.UpdateBoard(ctx, repo.UpdateBoardParams{
+		ID:       currentBoard.ID,
+		Name:     currentBoard.Name,
+		Elements: elements,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update board: %w", err)
+	}
+
+	if err := recordBoardRevision(ctx, qtx, &s.config.Board, board.ID, req.UserID, board.Elements, merged); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.thumbnails.Schedule(board.ID)
+
+	ids := make([]string, len(imported))
+	for i, element := range imported {
+		ids[i] = element.ID
+	}
+	return &dto.ImportMermaidResponse{
+		Board:      toBoardResponse(board),
+		ElementIDs: ids,
+	}, nil
+}
+
+// mermaidElements lays out a Mermaid diagram so that it does not overlap the
+// existing elements: to their right, aligned with their top edge.
+func mermaidElements(source string, existing []excalidraw.Element) ([]excalidraw.Element, error) {
+	diagram, err := mermaid.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidInput)
+	}
+
+	elements := mermaid.Elements(diagram, excalidraw.NewID)
+	if _, minY, maxX, _, ok := excalidraw.Bounds(existing); ok {
+		excalidraw.Translate(elements, maxX+importGap, minY)
+	}
+	return elements, nil
+}
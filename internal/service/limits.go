@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"draw/internal/db/repo"
+	"draw/pkg/config"
+	"draw/pkg/excalidraw"
+)
+
+// checkElementLimits reports elements that do not fit in a board as a
+// validation error, so the caller learns which elements to trim.
+func checkElementLimits(cfg *config.LimitsConfig, elements []excalidraw.Element) error {
+	err := excalidraw.CheckLimits(elements, excalidraw.Limits{
+		MaxElements:   cfg.MaxElements,
+		MaxTextLength: cfg.MaxTextLength,
+	})
+	if err != nil {
+		return fmt.Errorf("board exceeds limits: %w", err)
+	}
+	return nil
+}
+
+// checkBoardQuota fails with ErrQuotaExceeded when userID cannot own another
+// board. Boards in the trash do not count. It locks the user until the
+// transaction ends, so it must run in the one that adds the board, or
+// concurrent requests could each see room for one more.
+func checkBoardQuota(ctx context.Context, q *repo.Queries, cfg *config.LimitsConfig, userID string) error {
+	if cfg.MaxBoardsPerUser <= 0 {
+		return nil
+	}
+	if err := q.LockUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+	count, err := q.CountBoardsByOwnerID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to count boards: %w", err)
+	}
+	if int(count) >= cfg.MaxBoardsPerUser {
+		return fmt.Errorf("you already own %d boards, the most allowed: %w", count, ErrQuotaExceeded)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const defaultSearchLimit = 20
+
+type SearchService interface {
+	Search(ctx context.Context, req dto.SearchRequest) (*dto.SearchResponse, error)
+}
+
+type searchService struct {
+	queries *repo.Queries
+	db      *pgxpool.Pool
+	config  *config.AppConfig
+}
+
+func NewSearchService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	config *config.AppConfig,
+) SearchService {
+	return &searchService{
+		db:      db,
+		queries: queries,
+		config:  config,
+	}
+}
+
+// Search finds boards the user is a member of by their name and the text of
+// their elements.
+func (s *searchService) Search(ctx context.Context, req dto.SearchRequest) (*dto.SearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, fmt.Errorf("search query is empty: %w", ErrInvalidInput)
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	rows, err := s.queries.SearchBoards(ctx, repo.SearchBoardsParams{
+		Query:    query,
+		UserID:   req.UserID,
+		PageSize: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search boards: %w", err)
+	}
+
+	results := make([]dto.SearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = dto.SearchResult{
+			BoardID:    row.ID,
+			Name:       row.Name,
+			OwnerID:    row.OwnerID,
+			Role:       row.Role,
+			UpdatedAt:  row.UpdatedAt,
+			Snippet:    highlightSnippet(row.Snippet),
+			ElementIDs: row.ElementIds,
+		}
+	}
+	return &dto.SearchResponse{
+		Results: results,
+	}, nil
+}
+
+// snippetMarks turns the control characters SearchBoards puts around matches
+// into <mark> tags once the snippet text has been escaped.
+var snippetMarks = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+func highlightSnippet(snippet string) string {
+	return snippetMarks.Replace(html.EscapeString(snippet))
+}
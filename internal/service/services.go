@@ -4,6 +4,8 @@ import (
 	"draw/internal/db/repo"
 	"draw/pkg/config"
 	"draw/pkg/inngest"
+	"draw/pkg/livekit"
+	"draw/pkg/storage"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -11,12 +13,37 @@ import (
 type Service struct {
 	UserService UserService
 	BoardService BoardService
+	BoardRevisionService BoardRevisionService
+	BoardMemberService BoardMemberService
+	ShareLinkService ShareLinkService
+	TemplateService TemplateService
+	ExportService ExportService
+	ImportService ImportService
+	SearchService SearchService
+	FolderService FolderService
+	TagService TagService
+	FileService FileService
+	ThumbnailService ThumbnailService
+	SessionService SessionService
 }
 
-func NewService(db *pgxpool.Pool, queries *repo.Queries, inngest *inngest.Inngest, cfg *config.AppConfig) *Service {
+func NewService(db *pgxpool.Pool, queries *repo.Queries, inngest *inngest.Inngest, store storage.Storage, sessions *livekit.Manager, cfg *config.AppConfig) *Service {
+	thumbnails := NewThumbnailService(db, queries, store, cfg)
 	return &Service{
-		UserService: NewUserService(db, queries),
-		BoardService: NewBoardService(db, queries, cfg),
+		UserService: NewUserService(db, queries, cfg),
+		BoardService: NewBoardService(db, queries, thumbnails, sessions, cfg),
+		BoardRevisionService: NewBoardRevisionService(db, queries, thumbnails, cfg),
+		BoardMemberService: NewBoardMemberService(db, queries, sessions, cfg),
+		ShareLinkService: NewShareLinkService(db, queries, cfg),
+		TemplateService: NewTemplateService(db, queries, cfg),
+		ExportService: NewExportService(db, queries, cfg),
+		ImportService: NewImportService(db, queries, thumbnails, cfg),
+		SearchService: NewSearchService(db, queries, cfg),
+		FolderService: NewFolderService(db, queries, cfg),
+		TagService: NewTagService(db, queries, cfg),
+		FileService: NewFileService(db, queries, store, cfg),
+		ThumbnailService: thumbnails,
+		SessionService: NewSessionService(db, queries, thumbnails, sessions, cfg),
 	}
 		
 }
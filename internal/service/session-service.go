@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+	"draw/pkg/livekit"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SessionService manages membership of a board's live session: the LiveKit
+// room in which a bot syncs edits and listens for voice commands.
+type SessionService interface {
+	// JoinSession starts the board's session if it is not running and
+	// returns a room token for the caller.
+	JoinSession(ctx context.Context, req dto.BoardSessionRequest) (*dto.JoinBoardSessionResponse, error)
+	LeaveSession(ctx context.Context, req dto.BoardSessionRequest) error
+	GetSession(ctx context.Context, req dto.BoardSessionRequest) (*dto.BoardSession, error)
+	// UpdateSession changes the settings of the board's running session.
+	UpdateSession(ctx context.Context, req dto.UpdateBoardSessionRequest) (*dto.BoardSession, error)
+}
+
+type sessionService struct {
+	queries    *repo.Queries
+	db         *pgxpool.Pool
+	thumbnails ThumbnailService
+	sessions   *livekit.Manager
+	config     *config.AppConfig
+}
+
+func NewSessionService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	thumbnails ThumbnailService,
+	sessions *livekit.Manager,
+	config *config.AppConfig,
+) SessionService {
+	return &sessionService{
+		db:         db,
+		queries:    queries,
+		thumbnails: thumbnails,
+		sessions:   sessions,
+		config:     config,
+	}
+}
+
+func (s *sessionService) JoinSession(ctx context.Context, req dto.BoardSessionRequest) (*dto.JoinBoardSessionResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.queries.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// The session is shared by everyone who joins the board; the first
+	// joiner's details seed it.
+	session, err := s.sessions.Join(boardID.String(), func() (*livekit.LiveKitSession, error) {
+		return livekit.NewLiveKitSession(
+			&user,
+			boardID.String(),
+			s.config,
+			boardSyncCallbacks(s.db, s.queries, s.thumbnails, s.config),
+		)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to join session: %w", err)
+	}
+
+	token, err := session.GenerateUserToken(&user, sessionAccess(role))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	return &dto.JoinBoardSessionResponse{
+		Token:   token,
+		Session: toBoardSessionDTO(session.State()),
+	}, nil
+}
+
+func (s *sessionService) LeaveSession(ctx context.Context, req dto.BoardSessionRequest) error {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return err
+	}
+	s.sessions.Leave(boardID.String(), req.UserID)
+	return nil
+}
+
+func (s *sessionService) GetSession(ctx context.Context, req dto.BoardSessionRequest) (*dto.BoardSession, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	session, ok := s.sessions.Session(boardID.String())
+	if !ok {
+		return &dto.BoardSession{
+			BotState:     "disconnected",
+			Participants: []dto.SessionParticipant{},
+		}, nil
+	}
+	state := toBoardSessionDTO(session.State())
+	return &state, nil
+}
+
+func (s *sessionService) UpdateSession(ctx context.Context, req dto.UpdateBoardSessionRequest) (*dto.BoardSession, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleEditor); err != nil {
+		return nil, err
+	}
+
+	session, ok := s.sessions.Session(boardID.String())
+	if !ok {
+		return nil, fmt.Errorf("board has no live session: %w", ErrNotFound)
+	}
+	session.SetVoiceReplies(*req.VoiceReplies)
+	state := toBoardSessionDTO(session.State())
+	return &state, nil
+}
+
+func toBoardSessionDTO(state livekit.SessionState) dto.BoardSession {
+	participants := make([]dto.SessionParticipant, 0, len(state.Participants))
+	for _, participant := range state.Participants {
+		participants = append(participants, dto.SessionParticipant{
+			Identity:   participant.Identity,
+			Name:       participant.Name,
+			Muted:      participant.Muted,
+			CanPublish: participant.CanPublish,
+		})
+	}
+	return dto.BoardSession{
+		Active:       true,
+		BotState:     state.BotState,
+		Participants: participants,
+		VoiceReplies: state.VoiceReplies,
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// shareTokenBytes is the amount of randomness in a share token.
+const shareTokenBytes = 24
+
+type ShareLinkService interface {
+	CreateShareLink(ctx context.Context, req dto.CreateShareLinkRequest) (*dto.ShareLink, error)
+	ListShareLinks(ctx context.Context, req dto.ListShareLinksRequest) (*dto.ListShareLinksResponse, error)
+	RevokeShareLink(ctx context.Context, req dto.RevokeShareLinkRequest) error
+	GetSharedBoard(ctx context.Context, req dto.GetSharedBoardRequest) (*dto.GetSharedBoardResponse, error)
+}
+
+type shareLinkService struct {
+	queries *repo.Queries
+	db      *pgxpool.Pool
+	config  *config.AppConfig
+}
+
+func NewShareLinkService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	config *config.AppConfig,
+) ShareLinkService {
+	return &shareLinkService{
+		db:      db,
+		queries: queries,
+		config:  config,
+	}
+}
+
+func (s *shareLinkService) CreateShareLink(ctx context.Context, req dto.CreateShareLinkRequest) (*dto.ShareLink, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("expiresAt must be in the future: %w", ErrInvalidInput)
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleEditor); err != nil {
+		return nil, err
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.queries.CreateBoardShareLink(ctx, repo.CreateBoardShareLinkParams{
+		BoardID:   boardID,
+		Token:     token,
+		CreatedBy: &req.UserID,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	shareLink := toShareLinkResponse(link)
+	return &shareLink, nil
+}
+
+func (s *shareLinkService) ListShareLinks(ctx context.Context, req dto.ListShareLinksRequest) (*dto.ListShareLinksResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleEditor); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListBoardShareLinks(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+
+	links := make([]dto.ShareLink, 0, len(rows))
+	for _, row := range rows {
+		links = append(links, toShareLinkResponse(row))
+	}
+	return &dto.ListShareLinksResponse{
+		Links: links,
+	}, nil
+}
+
+func (s *shareLinkService) RevokeShareLink(ctx context.Context, req dto.RevokeShareLinkRequest) error {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return err
+	}
+	linkID, err := uuid.Parse(req.LinkID)
+	if err != nil {
+		return fmt.Errorf("link id %q: %w", req.LinkID, ErrInvalidInput)
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleEditor); err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteBoardShareLink(ctx, repo.DeleteBoardShareLinkParams{
+		ID:      linkID,
+		BoardID: boardID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("failed to revoke share link: %w", ErrNotFound)
+	}
+	return nil
+}
+
+// GetSharedBoard resolves a share token without any user context. Unknown,
+// revoked and expired tokens, and links to trashed boards, are
+// indistinguishable to the caller and are not counted as accesses.
+func (s *shareLinkService) GetSharedBoard(ctx context.Context, req dto.GetSharedBoardRequest) (*dto.GetSharedBoardResponse, error) {
+	link, err := s.queries.AccessBoardShareLink(ctx, req.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share link: %w", dbError(err))
+	}
+
+	board, err := s.queries.GetBoardByID(ctx, link.BoardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+
+	return &dto.GetSharedBoardResponse{
+		Board: dto.SharedBoard{
+			ID:       board.ID,
+			Name:     board.Name,
+			Elements: board.Elements,
+			Version:  board.Version,
+		},
+	}, nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func toShareLinkResponse(link repo.BoardShareLink) dto.ShareLink {
+	return dto.ShareLink{
+		ID:             link.ID,
+		BoardID:        link.BoardID,
+		Token:          link.Token,
+		CreatedBy:      link.CreatedBy,
+		ExpiresAt:      link.ExpiresAt,
+		AccessCount:    link.AccessCount,
+		LastAccessedAt: link.LastAccessedAt,
+		CreatedAt:      link.CreatedAt,
+	}
+}
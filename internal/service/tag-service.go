@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	maxTagLength = 64
+	maxBoardTags = 20
+)
+
+// TagService manages free-form labels a user puts on boards. Like folders,
+// tags belong to the user, so members of a shared board each keep their own.
+type TagService interface {
+	ListTags(ctx context.Context, req dto.ListTagsRequest) (*dto.ListTagsResponse, error)
+	TagBoard(ctx context.Context, req dto.TagBoardRequest) (*dto.BoardTagsResponse, error)
+	UntagBoard(ctx context.Context, req dto.UntagBoardRequest) (*dto.BoardTagsResponse, error)
+}
+
+type tagService struct {
+	queries *repo.Queries
+	db      *pgxpool.Pool
+	config  *config.AppConfig
+}
+
+func NewTagService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	config *config.AppConfig,
+) TagService {
+	return &tagService{
+		db:      db,
+		queries: queries,
+		config:  config,
+	}
+}
+
+func (s *tagService) ListTags(ctx context.Context, req dto.ListTagsRequest) (*dto.ListTagsResponse, error) {
+	rows, err := s.queries.ListUserTags(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := make([]dto.Tag, 0, len(rows))
+	for _, row := range rows {
+		tags = append(tags, dto.Tag{
+			Name:       row.Tag,
+			BoardCount: row.BoardCount,
+		})
+	}
+	return &dto.ListTagsResponse{
+		Tags: tags,
+	}, nil
+}
+
+func (s *tagService) TagBoard(ctx context.Context, req dto.TagBoardRequest) (*dto.BoardTagsResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(req.Tags))
+	for _, raw := range req.Tags {
+		tag := normalizeTag(raw)
+		if tag == "" {
+			return nil, fmt.Errorf("tags must not be empty: %w", ErrInvalidInput)
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters: %w", tag, maxTagLength, ErrInvalidInput)
+		}
+		tags = append(tags, tag)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	if _, err := authorizeBoard(ctx, qtx, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+	tags, err = qtx.AddBoardMemberTags(ctx, repo.AddBoardMemberTagsParams{
+		Tags:    tags,
+		BoardID: boardID,
+		UserID:  req.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag board: %w", dbError(err))
+	}
+	if len(tags) > maxBoardTags {
+		return nil, fmt.Errorf("a board can have at most %d tags: %w", maxBoardTags, ErrInvalidInput)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &dto.BoardTagsResponse{
+		BoardID: boardID,
+		Tags:    tags,
+	}, nil
+}
+
+func (s *tagService) UntagBoard(ctx context.Context, req dto.UntagBoardRequest) (*dto.BoardTagsResponse, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.queries.RemoveBoardMemberTag(ctx, repo.RemoveBoardMemberTagParams{
+		Tag:     normalizeTag(req.Tag),
+		BoardID: boardID,
+		UserID:  req.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to untag board: %w", dbError(err))
+	}
+	return &dto.BoardTagsResponse{
+		BoardID: boardID,
+		Tags:    tags,
+	}, nil
+}
+
+// normalizeTag folds case and collapses whitespace so that "Q3 Plans" and
+// " q3  plans" are the same tag.
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
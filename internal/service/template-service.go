@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+	"draw/pkg/excalidraw"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TemplateService interface {
+	ListTemplates(ctx context.Context, req dto.ListTemplatesRequest) (*dto.ListTemplatesResponse, error)
+	GetTemplate(ctx context.Context, req dto.GetTemplateRequest) (*dto.Template, error)
+	CreateTemplate(ctx context.Context, req dto.CreateTemplateRequest) (*dto.Template, error)
+	DeleteTemplate(ctx context.Context, req dto.DeleteTemplateRequest) error
+}
+
+type templateService struct {
+	queries *repo.Queries
+	db      *pgxpool.Pool
+	config  *config.AppConfig
+}
+
+func NewTemplateService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	config *config.AppConfig,
+) TemplateService {
+	return &templateService{
+		db:      db,
+		queries: queries,
+		config:  config,
+	}
+}
+
+// ListTemplates returns the built-in templates followed by the user's own.
+func (s *templateService) ListTemplates(ctx context.Context, req dto.ListTemplatesRequest) (*dto.ListTemplatesResponse, error) {
+	rows, err := s.queries.ListBoardTemplates(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	templates := make([]dto.Template, 0, len(rows))
+	for _, row := range rows {
+		templates = append(templates, dto.Template{
+			ID:           row.ID,
+			Name:         row.Name,
+			Description:  row.Description,
+			OwnerID:      row.OwnerID,
+			BuiltIn:      row.OwnerID == nil,
+			ElementCount: row.ElementCount,
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+	return &dto.ListTemplatesResponse{
+		Templates: templates,
+	}, nil
+}
+
+func (s *templateService) GetTemplate(ctx context.Context, req dto.GetTemplateRequest) (*dto.Template, error) {
+	templateID, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("template id %q: %w", req.TemplateID, ErrInvalidInput)
+	}
+
+	template, err := s.queries.GetBoardTemplate(ctx, repo.GetBoardTemplateParams{
+		ID:     templateID,
+		UserID: req.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", dbError(err))
+	}
+
+	return toTemplateResponse(template)
+}
+
+func (s *templateService) CreateTemplate(ctx context.Context, req dto.CreateTemplateRequest) (*dto.Template, error) {
+	var elements json.RawMessage
+	switch {
+	case req.BoardID != "" && req.Elements != nil:
+		return nil, fmt.Errorf("boardId and elements are mutually exclusive: %w", ErrInvalidInput)
+	case req.BoardID != "":
+		boardID, err := parseBoardID(req.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+			return nil, err
+		}
+		board, err := s.queries.GetBoardByID(ctx, boardID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+		}
+		elements = board.Elements
+	case req.Elements != nil:
+		if _, err := excalidraw.ParseAndValidate(req.Elements); err != nil {
+			return nil, fmt.Errorf("failed to validate elements: %w", err)
+		}
+		elements = req.Elements
+	default:
+		return nil, fmt.Errorf("either boardId or elements is required: %w", ErrInvalidInput)
+	}
+
+	template, err := s.queries.CreateBoardTemplate(ctx, repo.CreateBoardTemplateParams{
+		Name:        req.Name,
+		Description: req.Description,
+		OwnerID:     &req.UserID,
+		Elements:    elements,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	return toTemplateResponse(template)
+}
+
+// DeleteTemplate deletes one of the user's templates. Built-in templates
+// cannot be deleted and report ErrNotFound like any template the user
+// does not own.
+func (s *templateService) DeleteTemplate(ctx context.Context, req dto.DeleteTemplateRequest) error {
+	templateID, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		return fmt.Errorf("template id %q: %w", req.TemplateID, ErrInvalidInput)
+	}
+
+	deleted, err := s.queries.DeleteBoardTemplate(ctx, repo.DeleteBoardTemplateParams{
+		ID:     templateID,
+		UserID: req.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("failed to delete template: %w", ErrNotFound)
+	}
+	return nil
+}
+
+func toTemplateResponse(template repo.BoardTemplate) (*dto.Template, error) {
+	elements, err := excalidraw.Parse(template.Elements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template elements: %w", err)
+	}
+	return &dto.Template{
+		ID:           template.ID,
+		Name:         template.Name,
+		Description:  template.Description,
+		OwnerID:      template.OwnerID,
+		BuiltIn:      template.OwnerID == nil,
+		ElementCount: int32(len(elements)),
+		CreatedAt:    template.CreatedAt,
+		Elements:     template.Elements,
+	}, nil
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"draw/internal/db/repo"
+	"draw/internal/dto"
+	"draw/pkg/config"
+	"draw/pkg/excalidraw"
+	"draw/pkg/export"
+	"draw/pkg/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	thumbnailWidth  = 320
+	thumbnailHeight = 200
+	// thumbnailGCBatch is how many orphaned thumbnails one collection pass
+	// deletes before checking for more.
+	thumbnailGCBatch = 100
+	// thumbnailStaleBatch is how many out-of-date thumbnails one sweep
+	// schedules.
+	thumbnailStaleBatch = 100
+)
+
+type ThumbnailService interface {
+	// Schedule asks for the board's thumbnail to be redrawn ThumbnailDelay
+	// from now. Scheduling a board that is already waiting is a no-op, so a
+	// burst of saves is drawn once, from the state at the end of the delay.
+	Schedule(boardID uuid.UUID)
+	// RenderDue draws the thumbnails whose delay has elapsed and returns how
+	// many were stored.
+	RenderDue(ctx context.Context) (int, error)
+	// ScheduleStale schedules boards whose thumbnail is missing or older
+	// than the board, such as those saved before thumbnails existed or
+	// whose last render failed, and returns how many it found.
+	ScheduleStale(ctx context.Context) (int, error)
+	GetThumbnail(ctx context.Context, req dto.GetBoardThumbnailRequest) (*dto.BoardThumbnail, error)
+	// GetSignedThumbnail serves the thumbnail URLs the board list hands out,
+	// which browsers load without a bearer token.
+	GetSignedThumbnail(ctx context.Context, req dto.GetSignedBoardThumbnailRequest) (*dto.BoardThumbnail, error)
+	// CollectOrphanedThumbnails deletes the thumbnails of purged boards and
+	// returns how many were removed.
+	CollectOrphanedThumbnails(ctx context.Context) (int64, error)
+}
+
+type thumbnailService struct {
+	queries *repo.Queries
+	db      *pgxpool.Pool
+	storage storage.Storage
+	config  *config.AppConfig
+
+	mu  sync.Mutex
+	due map[uuid.UUID]time.Time // When each scheduled board may be drawn
+}
+
+func NewThumbnailService(
+	db *pgxpool.Pool,
+	queries *repo.Queries,
+	storage storage.Storage,
+	config *config.AppConfig,
+) ThumbnailService {
+	return &thumbnailService{
+		db:      db,
+		queries: queries,
+		storage: storage,
+		config:  config,
+		due:     make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (s *thumbnailService) Schedule(boardID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.due[boardID]; !ok {
+		s.due[boardID] = time.Now().Add(s.config.Board.ThumbnailDelay)
+	}
+}
+
+func (s *thumbnailService) RenderDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	var boardIDs []uuid.UUID
+	s.mu.Lock()
+	for boardID, at := range s.due {
+		if !at.After(now) {
+			boardIDs = append(boardIDs, boardID)
+			delete(s.due, boardID)
+		}
+	}
+	s.mu.Unlock()
+
+	// A failed render is not retried here; ScheduleStale picks it up again
+	// on its next sweep.
+	var errs []error
+	rendered := 0
+	for _, boardID := range boardIDs {
+		if err := ctx.Err(); err != nil {
+			return rendered, err
+		}
+		if err := s.render(ctx, boardID); err != nil {
+			errs = append(errs, fmt.Errorf("board %s: %w", boardID, err))
+			continue
+		}
+		rendered++
+	}
+	return rendered, errors.Join(errs...)
+}
+
+func (s *thumbnailService) ScheduleStale(ctx context.Context) (int, error) {
+	boardIDs, err := s.queries.ListStaleBoardThumbnails(ctx, thumbnailStaleBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list stale thumbnails: %w", err)
+	}
+	for _, boardID := range boardIDs {
+		s.Schedule(boardID)
+	}
+	return len(boardIDs), nil
+}
+
+func (s *thumbnailService) render(ctx context.Context, boardID uuid.UUID) error {
+	board, err := s.queries.GetBoardByID(ctx, boardID)
+	if errors.Is(dbError(err), ErrNotFound) {
+		// Deleted since it was scheduled.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get board: %w", err)
+	}
+	elements, err := excalidraw.Parse(board.Elements)
+	if err != nil {
+		return fmt.Errorf("failed to parse elements: %w", err)
+	}
+	data, err := export.Thumbnail(elements, thumbnailWidth, thumbnailHeight)
+	if err != nil {
+		return fmt.Errorf("failed to render thumbnail: %w", err)
+	}
+
+	key := boardThumbnailKey(boardID)
+	if err := s.storage.Put(ctx, key, data, "image/png"); err != nil {
+		return fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+	_, err = s.queries.UpsertBoardThumbnail(ctx, repo.UpsertBoardThumbnailParams{
+		BoardID:    boardID,
+		StorageKey: key,
+		Version:    board.Version,
+	})
+	if err != nil && !errors.Is(dbError(err), ErrNotFound) {
+		// No row means a newer version was recorded concurrently.
+		return fmt.Errorf("failed to record thumbnail: %w", err)
+	}
+	return nil
+}
+
+func (s *thumbnailService) GetThumbnail(ctx context.Context, req dto.GetBoardThumbnailRequest) (*dto.BoardThumbnail, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeBoard(ctx, s.queries, boardID, req.UserID, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.loadThumbnail(ctx, boardID)
+}
+
+func (s *thumbnailService) GetSignedThumbnail(ctx context.Context, req dto.GetSignedBoardThumbnailRequest) (*dto.BoardThumbnail, error) {
+	boardID, err := parseBoardID(req.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().Unix() >= req.Expires {
+		return nil, fmt.Errorf("thumbnail link has expired: %w", ErrForbidden)
+	}
+	want := signThumbnailURL(&s.config.Storage, boardID, req.Version, req.Expires)
+	if !hmac.Equal([]byte(req.Signature), []byte(want)) {
+		return nil, fmt.Errorf("thumbnail link is not valid: %w", ErrForbidden)
+	}
+	// Trashed boards keep their thumbnail until they are purged, but it is
+	// not shown meanwhile.
+	if _, err := s.queries.GetBoardByID(ctx, boardID); err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", dbError(err))
+	}
+	return s.loadThumbnail(ctx, boardID)
+}
+
+func (s *thumbnailService) loadThumbnail(ctx context.Context, boardID uuid.UUID) (*dto.BoardThumbnail, error) {
+	thumbnail, err := s.queries.GetBoardThumbnail(ctx, boardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thumbnail: %w", dbError(err))
+	}
+	body, err := s.storage.Get(ctx, thumbnail.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("thumbnail is missing from storage: %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read thumbnail: %w", err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read thumbnail: %w", err)
+	}
+	return &dto.BoardThumbnail{
+		Data:    data,
+		Version: thumbnail.Version,
+	}, nil
+}
+
+func (s *thumbnailService) CollectOrphanedThumbnails(ctx context.Context) (int64, error) {
+	var collected int64
+	for {
+		thumbnails, err := s.queries.ListOrphanedBoardThumbnails(ctx, thumbnailGCBatch)
+		if err != nil {
+			return collected, fmt.Errorf("failed to list orphaned thumbnails: %w", err)
+		}
+		for _, thumbnail := range thumbnails {
+			if err := s.storage.Delete(ctx, thumbnail.StorageKey); err != nil {
+				return collected, fmt.Errorf("failed to delete thumbnail %s: %w", thumbnail.StorageKey, err)
+			}
+			if _, err := s.queries.DeleteBoardThumbnail(ctx, thumbnail.BoardID); err != nil {
+				return collected, fmt.Errorf("failed to delete thumbnail record: %w", err)
+			}
+			collected++
+		}
+		if len(thumbnails) < thumbnailGCBatch {
+			return collected, nil
+		}
+	}
+}
+
+func boardThumbnailKey(boardID uuid.UUID) string {
+	return fmt.Sprintf("boards/%s/thumbnail.png", boardID)
+}
+
+// boardThumbnailURL is where the board list points clients for a thumbnail.
+// The URL is signed so that an <img> can load it without a bearer token. The
+// version makes each drawing a distinct URL, and expiry is rounded to whole
+// TTLs so that the URL, and the browser's cached copy, stays the same for a
+// while: it is valid for between one and two TTLs.
+func boardThumbnailURL(cfg *config.StorageConfig, boardID uuid.UUID, version int64, now time.Time) string {
+	ttl := max(int64(cfg.SignedURLTTL/time.Second), 1)
+	expires := (now.Unix()/ttl + 2) * ttl
+	return fmt.Sprintf("/thumbnails/%s?v=%d&expires=%d&sig=%s",
+		boardID, version, expires, signThumbnailURL(cfg, boardID, version, expires))
+}
+
+func signThumbnailURL(cfg *config.StorageConfig, boardID uuid.UUID, version int64, expires int64) string {
+	mac := hmac.New(sha256.New, cfg.URLSigningKey)
+	fmt.Fprintf(mac, "thumbnail:%s:%d:%d", boardID, version, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -2,29 +2,35 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"draw/internal/db/repo"
 	"draw/internal/dto"
+	"draw/pkg/config"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type UserService interface {
 	GetUserByID(ctx context.Context, id string) (*dto.UserResponse, error)
+	GetUsage(ctx context.Context, req dto.GetUserUsageRequest) (*dto.UserUsage, error)
 }
 
 type userService struct {
 	queries *repo.Queries
 	db      *pgxpool.Pool
+	config  *config.AppConfig
 }
 
 func NewUserService(
 	db *pgxpool.Pool,
 	queries *repo.Queries,
+	config *config.AppConfig,
 ) UserService {
 	return &userService{
 		db:      db,
 		queries: queries,
+		config:  config,
 	}
 }
 func (s *userService) GetUserByID(ctx context.Context, id string) (*dto.UserResponse, error) {
@@ -36,4 +42,24 @@ func (s *userService) GetUserByID(ctx context.Context, id string) (*dto.UserResp
 		ID: user.ID,
 		Name: user.Name,
 	}, nil
-}
\ No newline at end of file
+}
+
+func (s *userService) GetUsage(ctx context.Context, req dto.GetUserUsageRequest) (*dto.UserUsage, error) {
+	usage, err := s.queries.GetUserUsage(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	limits := s.config.Limits
+	return &dto.UserUsage{
+		Boards:        usage.BoardCount,
+		TrashedBoards: usage.TrashedBoardCount,
+		StorageBytes:  usage.ElementsSize + usage.FilesSize,
+		Limits: dto.UserLimits{
+			Boards:           limits.MaxBoardsPerUser,
+			ElementsPerBoard: limits.MaxElements,
+			TextLength:       limits.MaxTextLength,
+			RequestSize:      limits.MaxRequestSize,
+			FileSize:         s.config.Storage.MaxFileSize,
+		},
+	}, nil
+}
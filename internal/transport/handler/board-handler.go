@@ -3,7 +3,11 @@ package handler
 import (
 	"draw/internal/dto"
 	"draw/internal/service"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,19 +25,13 @@ func NewBoardHandler(boardService service.BoardService) *BoardHandler {
 func (h *BoardHandler) CreateBoard(c *gin.Context) {
 	var req dto.CreateBoardRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Message: "Invalid request",
-			Error:   err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 	req.UserID = c.MustGet("userId").(string)
 	board, err := h.boardService.CreateBoard(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Message: "Failed to create board",
-			Error:   err.Error(),
-		})
+		respondError(c, "Failed to create board", err)
 		return
 	}
 	c.JSON(http.StatusOK, dto.SuccessResponse{
@@ -50,12 +48,10 @@ func (h *BoardHandler) GetBoard(c *gin.Context) {
 		UserID:  userId,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Message: "Failed to get board",
-			Error:   err.Error(),
-		})
+		respondError(c, "Failed to get board", err)
 		return
 	}
+	c.Header("ETag", boardETag(board.Board.Version))
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Message: "Board fetched",
 		Data:    board,
@@ -63,17 +59,20 @@ func (h *BoardHandler) GetBoard(c *gin.Context) {
 }
 
 func (h *BoardHandler) GetBoardsByUserID(c *gin.Context) {
-	userId := c.MustGet("userId").(string)
-	boards, err := h.boardService.GetBoardsByUserID(c.Request.Context(), dto.GetBoardsByUserIDRequest{
-		UserID: userId,
-	})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Message: "Failed to get boards",
+	var req dto.GetBoardsByUserIDRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
 			Error:   err.Error(),
 		})
 		return
 	}
+	req.UserID = c.MustGet("userId").(string)
+	boards, err := h.boardService.GetBoardsByUserID(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to get boards", err)
+		return
+	}
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Message: "Boards fetched",
 		Data:    boards,
@@ -83,24 +82,150 @@ func (h *BoardHandler) GetBoardsByUserID(c *gin.Context) {
 func (h *BoardHandler) UpdateBoard(c *gin.Context) {
 	var req dto.UpdateBoardRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	ifMatch, err := parseIfMatch(c.GetHeader("If-Match"))
+	if errors.Is(err, errWeakETag) {
+		c.JSON(http.StatusPreconditionFailed, dto.ErrorResponse{
+			Message: "Precondition failed",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Message: "Invalid request",
+			Message: "Invalid If-Match header",
 			Error:   err.Error(),
 		})
 		return
 	}
 	req.BoardID = c.Param("id")
 	req.UserID = c.MustGet("userId").(string)
+	req.IfMatch = ifMatch
 	resp, err := h.boardService.UpdateBoard(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Message: "Failed to update board",
-			Error:   err.Error(),
-		})
+		respondError(c, "Failed to update board", err)
 		return
 	}
+	c.Header("ETag", boardETag(resp.Board.Version))
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Message: "Board updated",
 		Data:    resp,
 	})
+}
+
+func (h *BoardHandler) PatchElements(c *gin.Context) {
+	var req dto.PatchBoardElementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	resp, err := h.boardService.PatchElements(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to patch elements", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Elements patched",
+		Data:    resp,
+	})
+}
+
+func (h *BoardHandler) DuplicateBoard(c *gin.Context) {
+	var req dto.DuplicateBoardRequest
+	// The body is optional: without a name the copy is named after the original.
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	board, err := h.boardService.DuplicateBoard(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to duplicate board", err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Board duplicated",
+		Data:    board,
+	})
+}
+
+func (h *BoardHandler) DeleteBoard(c *gin.Context) {
+	err := h.boardService.DeleteBoard(c.Request.Context(), dto.DeleteBoardRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to delete board", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Board moved to trash",
+	})
+}
+
+func (h *BoardHandler) GetTrash(c *gin.Context) {
+	boards, err := h.boardService.GetTrash(c.Request.Context(), dto.GetTrashRequest{
+		UserID: c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to get trash", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Trash fetched",
+		Data:    boards,
+	})
+}
+
+func (h *BoardHandler) RestoreBoard(c *gin.Context) {
+	resp, err := h.boardService.RestoreBoard(c.Request.Context(), dto.RestoreBoardRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to restore board", err)
+		return
+	}
+	c.Header("ETag", boardETag(resp.Board.Version))
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Board restored",
+		Data:    resp,
+	})
+}
+
+// boardETag formats a board version as a strong entity tag.
+func boardETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// errWeakETag is returned for a weak entity tag in If-Match, which never
+// matches: If-Match compares tags strongly (RFC 9110, section 13.1.1).
+var errWeakETag = errors.New("weak entity tags never match If-Match")
+
+// parseIfMatch reads the board version from an If-Match header. An absent
+// header or "*" matches any version and yields nil.
+func parseIfMatch(header string) (*int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+	if strings.HasPrefix(header, "W/") {
+		return nil, errWeakETag
+	}
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		unquoted = header
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("expected a board version entity tag, got %s", header)
+	}
+	return &version, nil
 }
\ No newline at end of file
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BoardMemberHandler struct {
+	memberService service.BoardMemberService
+}
+
+func NewBoardMemberHandler(memberService service.BoardMemberService) *BoardMemberHandler {
+	return &BoardMemberHandler{
+		memberService: memberService,
+	}
+}
+
+func (h *BoardMemberHandler) ListMembers(c *gin.Context) {
+	resp, err := h.memberService.ListMembers(c.Request.Context(), dto.ListBoardMembersRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to list members", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Members fetched",
+		Data:    resp,
+	})
+}
+
+func (h *BoardMemberHandler) AddMember(c *gin.Context) {
+	var req dto.AddBoardMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	member, err := h.memberService.AddMember(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to add member", err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Member added",
+		Data:    member,
+	})
+}
+
+func (h *BoardMemberHandler) UpdateMember(c *gin.Context) {
+	var req dto.UpdateBoardMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	req.MemberID = c.Param("userId")
+	member, err := h.memberService.UpdateMember(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to update member", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Member updated",
+		Data:    member,
+	})
+}
+
+func (h *BoardMemberHandler) RemoveMember(c *gin.Context) {
+	err := h.memberService.RemoveMember(c.Request.Context(), dto.RemoveBoardMemberRequest{
+		BoardID:  c.Param("id"),
+		UserID:   c.MustGet("userId").(string),
+		MemberID: c.Param("userId"),
+	})
+	if err != nil {
+		respondError(c, "Failed to remove member", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Member removed",
+	})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BoardRevisionHandler struct {
+	revisionService service.BoardRevisionService
+}
+
+func NewBoardRevisionHandler(revisionService service.BoardRevisionService) *BoardRevisionHandler {
+	return &BoardRevisionHandler{
+		revisionService: revisionService,
+	}
+}
+
+func (h *BoardRevisionHandler) ListRevisions(c *gin.Context) {
+	resp, err := h.revisionService.ListRevisions(c.Request.Context(), dto.ListBoardRevisionsRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to list revisions", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Revisions fetched",
+		Data:    resp,
+	})
+}
+
+func (h *BoardRevisionHandler) GetRevision(c *gin.Context) {
+	revision, ok := revisionParam(c)
+	if !ok {
+		return
+	}
+	resp, err := h.revisionService.GetRevision(c.Request.Context(), dto.GetBoardRevisionRequest{
+		BoardID:  c.Param("id"),
+		UserID:   c.MustGet("userId").(string),
+		Revision: revision,
+		Compare:  c.Query("compare"),
+	})
+	if err != nil {
+		respondError(c, "Failed to get revision", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Revision fetched",
+		Data:    resp,
+	})
+}
+
+func (h *BoardRevisionHandler) RestoreRevision(c *gin.Context) {
+	revision, ok := revisionParam(c)
+	if !ok {
+		return
+	}
+	resp, err := h.revisionService.RestoreRevision(c.Request.Context(), dto.RestoreBoardRevisionRequest{
+		BoardID:  c.Param("id"),
+		UserID:   c.MustGet("userId").(string),
+		Revision: revision,
+	})
+	if err != nil {
+		respondError(c, "Failed to restore revision", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Revision restored",
+		Data:    resp,
+	})
+}
+
+func revisionParam(c *gin.Context) (int32, bool) {
+	revision, err := strconv.ParseInt(c.Param("rev"), 10, 32)
+	if err != nil || revision < 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid revision",
+			Error:   "revision must be a positive integer",
+		})
+		return 0, false
+	}
+	return int32(revision), true
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+	"draw/pkg/excalidraw"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondError writes err as an ErrorResponse, picking the status code from
+// the kind of error returned by the service. Unrecognised errors are 500s.
+func respondError(c *gin.Context, message string, err error) {
+	var invalidElements *excalidraw.ValidationError
+	var boardConflict *service.BoardConflictError
+	switch {
+	case errors.As(err, &invalidElements):
+		c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+			Details: dto.InvalidElementsDetails{
+				ElementIDs: invalidElements.ElementIDs(),
+				Issues:     invalidElements.Issues,
+			},
+		})
+	case errors.As(err, &boardConflict):
+		c.Header("ETag", boardETag(boardConflict.Version))
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+			Details: dto.BoardConflictDetails{
+				Version:  boardConflict.Version,
+				Elements: boardConflict.Elements,
+			},
+		})
+	case errors.Is(err, service.ErrConflict):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrQuotaExceeded):
+		c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
+
+// respondBindError reports a request body that could not be decoded. Bodies
+// cut off by the size limit are 413s rather than malformed requests.
+func respondBindError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+			Message: "Request too large",
+			Error:   fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit),
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+		Message: "Invalid request",
+		Error:   err.Error(),
+	})
+}
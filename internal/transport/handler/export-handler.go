@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	exportService service.ExportService
+}
+
+func NewExportHandler(exportService service.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+func (h *ExportHandler) ExportSVG(c *gin.Context) {
+	req, ok := exportRequest(c)
+	if !ok {
+		return
+	}
+	resp, err := h.exportService.ExportSVG(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to export board", err)
+		return
+	}
+	respondExport(c, resp)
+}
+
+func (h *ExportHandler) ExportPNG(c *gin.Context) {
+	req, ok := exportRequest(c)
+	if !ok {
+		return
+	}
+	resp, err := h.exportService.ExportPNG(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to export board", err)
+		return
+	}
+	respondExport(c, resp)
+}
+
+func (h *ExportHandler) ExportPDF(c *gin.Context) {
+	req, ok := exportRequest(c)
+	if !ok {
+		return
+	}
+	resp, err := h.exportService.ExportPDF(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to export board", err)
+		return
+	}
+	respondExport(c, resp)
+}
+
+func (h *ExportHandler) ExportMermaid(c *gin.Context) {
+	req, ok := exportRequest(c)
+	if !ok {
+		return
+	}
+	resp, err := h.exportService.ExportMermaid(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to export board", err)
+		return
+	}
+	respondExport(c, resp)
+}
+
+func (h *ExportHandler) ExportDOT(c *gin.Context) {
+	req, ok := exportRequest(c)
+	if !ok {
+		return
+	}
+	resp, err := h.exportService.ExportDOT(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to export board", err)
+		return
+	}
+	respondExport(c, resp)
+}
+
+func exportRequest(c *gin.Context) (dto.ExportBoardRequest, bool) {
+	var req dto.ExportBoardRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return req, false
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	return req, true
+}
+
+// respondExport writes an exported file. Browsers show it inline; the file
+// name is used when it is saved. The X-Skipped-Elements header counts the
+// elements left out of the export; the file itself says which and why.
+func respondExport(c *gin.Context, resp *dto.ExportBoardResponse) {
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", resp.FileName))
+	if resp.Skipped > 0 {
+		c.Header("X-Skipped-Elements", strconv.Itoa(resp.Skipped))
+	}
+	c.Data(http.StatusOK, resp.ContentType, resp.Content)
+}
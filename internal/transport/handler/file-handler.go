@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FileHandler struct {
+	fileService service.FileService
+}
+
+func NewFileHandler(fileService service.FileService) *FileHandler {
+	return &FileHandler{
+		fileService: fileService,
+	}
+}
+
+// UploadFile stores the raw request body under the element fileId.
+func (h *FileHandler) UploadFile(c *gin.Context) {
+	file, err := h.fileService.UploadFile(c.Request.Context(), dto.UploadBoardFileRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+		FileID:  c.Param("fileId"),
+		Body:    c.Request.Body,
+	})
+	if err != nil {
+		respondError(c, "Failed to upload file", err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "File uploaded",
+		Data:    file,
+	})
+}
+
+func (h *FileHandler) GetFile(c *gin.Context) {
+	file, err := h.fileService.GetFile(c.Request.Context(), dto.GetBoardFileRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+		FileID:  c.Param("fileId"),
+	})
+	if err != nil {
+		respondError(c, "Failed to get file", err)
+		return
+	}
+	defer file.Body.Close()
+
+	headers := map[string]string{
+		// A file id keeps the bytes it was first uploaded with, so a given
+		// URL never changes.
+		"Cache-Control":          "private, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	}
+	if file.MimeType == "image/svg+xml" {
+		// SVG can carry script; keep it inert when opened directly.
+		headers["Content-Security-Policy"] = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+	}
+	c.DataFromReader(http.StatusOK, file.Size, file.MimeType, file.Body, headers)
+}
+
+func (h *FileHandler) ListFiles(c *gin.Context) {
+	resp, err := h.fileService.ListFiles(c.Request.Context(), dto.ListBoardFilesRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to list files", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Files fetched",
+		Data:    resp,
+	})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FolderHandler struct {
+	folderService service.FolderService
+}
+
+func NewFolderHandler(folderService service.FolderService) *FolderHandler {
+	return &FolderHandler{
+		folderService: folderService,
+	}
+}
+
+func (h *FolderHandler) ListFolders(c *gin.Context) {
+	resp, err := h.folderService.ListFolders(c.Request.Context(), dto.ListFoldersRequest{
+		UserID: c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to list folders", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Folders fetched",
+		Data:    resp,
+	})
+}
+
+func (h *FolderHandler) CreateFolder(c *gin.Context) {
+	var req dto.CreateFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.UserID = c.MustGet("userId").(string)
+	folder, err := h.folderService.CreateFolder(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to create folder", err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Folder created",
+		Data:    folder,
+	})
+}
+
+func (h *FolderHandler) UpdateFolder(c *gin.Context) {
+	var req dto.UpdateFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.FolderID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	folder, err := h.folderService.UpdateFolder(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to update folder", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Folder updated",
+		Data:    folder,
+	})
+}
+
+func (h *FolderHandler) DeleteFolder(c *gin.Context) {
+	err := h.folderService.DeleteFolder(c.Request.Context(), dto.DeleteFolderRequest{
+		FolderID: c.Param("id"),
+		UserID:   c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to delete folder", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Folder deleted",
+	})
+}
+
+func (h *FolderHandler) MoveBoard(c *gin.Context) {
+	var req dto.MoveBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	resp, err := h.folderService.MoveBoard(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to move board", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Board moved",
+		Data:    resp,
+	})
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	importService service.ImportService
+}
+
+func NewImportHandler(importService service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+func (h *ImportHandler) ImportMermaid(c *gin.Context) {
+	var req dto.ImportMermaidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	resp, err := h.importService.ImportMermaid(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to import diagram", err)
+		return
+	}
+	c.Header("ETag", boardETag(resp.Board.Version))
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Diagram imported",
+		Data:    resp,
+	})
+}
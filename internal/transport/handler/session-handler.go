@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionService service.SessionService
+}
+
+func NewSessionHandler(sessionService service.SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+func (h *SessionHandler) JoinSession(c *gin.Context) {
+	resp, err := h.sessionService.JoinSession(c.Request.Context(), dto.BoardSessionRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to join session", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Session joined",
+		Data:    resp,
+	})
+}
+
+func (h *SessionHandler) LeaveSession(c *gin.Context) {
+	err := h.sessionService.LeaveSession(c.Request.Context(), dto.BoardSessionRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to leave session", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Session left",
+	})
+}
+
+func (h *SessionHandler) GetSession(c *gin.Context) {
+	session, err := h.sessionService.GetSession(c.Request.Context(), dto.BoardSessionRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to get session", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Session fetched",
+		Data:    session,
+	})
+}
+
+func (h *SessionHandler) UpdateSession(c *gin.Context) {
+	var req dto.UpdateBoardSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	session, err := h.sessionService.UpdateSession(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to update session", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Session updated",
+		Data:    session,
+	})
+}
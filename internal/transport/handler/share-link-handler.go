@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareLinkHandler struct {
+	shareLinkService service.ShareLinkService
+}
+
+func NewShareLinkHandler(shareLinkService service.ShareLinkService) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		shareLinkService: shareLinkService,
+	}
+}
+
+func (h *ShareLinkHandler) CreateShareLink(c *gin.Context) {
+	var req dto.CreateShareLinkRequest
+	// The body is optional: a link without an expiry needs no fields.
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid request",
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	link, err := h.shareLinkService.CreateShareLink(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to create share link", err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Share link created",
+		Data:    link,
+	})
+}
+
+func (h *ShareLinkHandler) ListShareLinks(c *gin.Context) {
+	resp, err := h.shareLinkService.ListShareLinks(c.Request.Context(), dto.ListShareLinksRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to list share links", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Share links fetched",
+		Data:    resp,
+	})
+}
+
+func (h *ShareLinkHandler) RevokeShareLink(c *gin.Context) {
+	err := h.shareLinkService.RevokeShareLink(c.Request.Context(), dto.RevokeShareLinkRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+		LinkID:  c.Param("linkId"),
+	})
+	if err != nil {
+		respondError(c, "Failed to revoke share link", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Share link revoked",
+	})
+}
+
+// GetSharedBoard serves a board to anonymous visitors holding a share token.
+func (h *ShareLinkHandler) GetSharedBoard(c *gin.Context) {
+	resp, err := h.shareLinkService.GetSharedBoard(c.Request.Context(), dto.GetSharedBoardRequest{
+		Token: c.Param("token"),
+	})
+	if err != nil {
+		respondError(c, "Failed to get shared board", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Board fetched",
+		Data:    resp,
+	})
+}
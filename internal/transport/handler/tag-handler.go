@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler struct {
+	tagService service.TagService
+}
+
+func NewTagHandler(tagService service.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+func (h *TagHandler) ListTags(c *gin.Context) {
+	resp, err := h.tagService.ListTags(c.Request.Context(), dto.ListTagsRequest{
+		UserID: c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to list tags", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Tags fetched",
+		Data:    resp,
+	})
+}
+
+func (h *TagHandler) TagBoard(c *gin.Context) {
+	var req dto.TagBoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.BoardID = c.Param("id")
+	req.UserID = c.MustGet("userId").(string)
+	resp, err := h.tagService.TagBoard(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to tag board", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Board tagged",
+		Data:    resp,
+	})
+}
+
+func (h *TagHandler) UntagBoard(c *gin.Context) {
+	resp, err := h.tagService.UntagBoard(c.Request.Context(), dto.UntagBoardRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+		Tag:     c.Param("tag"),
+	})
+	if err != nil {
+		respondError(c, "Failed to untag board", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Board untagged",
+		Data:    resp,
+	})
+}
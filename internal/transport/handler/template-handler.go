@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateHandler struct {
+	templateService service.TemplateService
+}
+
+func NewTemplateHandler(templateService service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+	}
+}
+
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	resp, err := h.templateService.ListTemplates(c.Request.Context(), dto.ListTemplatesRequest{
+		UserID: c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to list templates", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Templates fetched",
+		Data:    resp,
+	})
+}
+
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	template, err := h.templateService.GetTemplate(c.Request.Context(), dto.GetTemplateRequest{
+		UserID:     c.MustGet("userId").(string),
+		TemplateID: c.Param("id"),
+	})
+	if err != nil {
+		respondError(c, "Failed to get template", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Template fetched",
+		Data:    template,
+	})
+}
+
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req dto.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.UserID = c.MustGet("userId").(string)
+	template, err := h.templateService.CreateTemplate(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to create template", err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Template created",
+		Data:    template,
+	})
+}
+
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	err := h.templateService.DeleteTemplate(c.Request.Context(), dto.DeleteTemplateRequest{
+		UserID:     c.MustGet("userId").(string),
+		TemplateID: c.Param("id"),
+	})
+	if err != nil {
+		respondError(c, "Failed to delete template", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Template deleted",
+	})
+}
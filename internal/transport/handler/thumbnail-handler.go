@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"draw/internal/dto"
+	"draw/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ThumbnailHandler struct {
+	thumbnailService service.ThumbnailService
+}
+
+func NewThumbnailHandler(thumbnailService service.ThumbnailService) *ThumbnailHandler {
+	return &ThumbnailHandler{
+		thumbnailService: thumbnailService,
+	}
+}
+
+func (h *ThumbnailHandler) GetThumbnail(c *gin.Context) {
+	thumbnail, err := h.thumbnailService.GetThumbnail(c.Request.Context(), dto.GetBoardThumbnailRequest{
+		BoardID: c.Param("id"),
+		UserID:  c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to get thumbnail", err)
+		return
+	}
+
+	// A URL naming the version served can be cached for good. Anything else
+	// may be redrawn at any time.
+	cacheControl := "private, no-cache"
+	if c.Query("v") == strconv.FormatInt(thumbnail.Version, 10) {
+		cacheControl = "private, max-age=31536000, immutable"
+	}
+	c.Header("Cache-Control", cacheControl)
+	c.Data(http.StatusOK, "image/png", thumbnail.Data)
+}
+
+// GetSignedThumbnail serves the signed thumbnail URLs from the board list. A
+// URL is fixed to one drawing and expires, so it is cached until then.
+func (h *ThumbnailHandler) GetSignedThumbnail(c *gin.Context) {
+	var req dto.GetSignedBoardThumbnailRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	req.BoardID = c.Param("id")
+	thumbnail, err := h.thumbnailService.GetSignedThumbnail(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, "Failed to get thumbnail", err)
+		return
+	}
+
+	cacheControl := "private, no-cache"
+	if thumbnail.Version == req.Version {
+		maxAge := max(req.Expires-time.Now().Unix(), 0)
+		cacheControl = fmt.Sprintf("private, max-age=%d, immutable", maxAge)
+	}
+	c.Header("Cache-Control", cacheControl)
+	c.Data(http.StatusOK, "image/png", thumbnail.Data)
+}
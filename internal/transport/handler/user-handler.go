@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"draw/internal/dto"
 	"draw/internal/service"
 	"net/http"
 
@@ -25,4 +26,19 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 	c.JSON(http.StatusOK, user)
-}
\ No newline at end of file
+}
+
+// GetUsage reports the caller's consumption against the configured limits.
+func (h *UserHandler) GetUsage(c *gin.Context) {
+	usage, err := h.userService.GetUsage(c.Request.Context(), dto.GetUserUsageRequest{
+		UserID: c.MustGet("userId").(string),
+	})
+	if err != nil {
+		respondError(c, "Failed to get usage", err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Usage fetched",
+		Data:    usage,
+	})
+}
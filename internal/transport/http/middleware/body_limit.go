@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"draw/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit caps the request body at limit bytes. A request that declares a
+// longer body is refused with 413 before any of it is read; otherwise reading
+// past the limit fails with *http.MaxBytesError, which handlers report as
+// 413.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit > 0 {
+			if c.Request.ContentLength > limit {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+					Message: "Request too large",
+					Error:   fmt.Sprintf("request body is larger than %d bytes", limit),
+				})
+				return
+			}
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+		c.Next()
+	}
+}
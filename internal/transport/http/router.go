@@ -17,8 +17,9 @@ func RegisterRoutes(r *gin.Engine, authKeys jwk.Set, app *app.App) {
 	r.Use(gin.Recovery())
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173", "http://127.0.0.1:5173", "http://localhost:9000", "http://127.0.0.1:9000"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-Match"},
+		ExposeHeaders:    []string{"ETag", "Content-Disposition", "X-Skipped-Elements"},
 		AllowCredentials: true,
 	}))
 
@@ -28,6 +29,14 @@ func RegisterRoutes(r *gin.Engine, authKeys jwk.Set, app *app.App) {
 		})
 	})
 
+	shareLinkHandler := handler.NewShareLinkHandler(app.Service.ShareLinkService)
+	r.GET("/shared/:token", shareLinkHandler.GetSharedBoard)
+
+	// Thumbnail URLs from the board list are signed in place of a bearer
+	// token, so that browsers can load them as images.
+	thumbnailHandler := handler.NewThumbnailHandler(app.Service.ThumbnailService)
+	r.GET("/thumbnails/:id", thumbnailHandler.GetSignedThumbnail)
+
 	// Middlewares
 	protected := r.Group("")
 	protected.Use(middleware.AuthMiddleware(authKeys))
@@ -36,11 +45,79 @@ func RegisterRoutes(r *gin.Engine, authKeys jwk.Set, app *app.App) {
 	// r.Any("/api/inngest", app.Inngest.Handler())
 
 	userHandler := handler.NewUserHandler(app.Service.UserService)
+	protected.GET("/users/me/usage", userHandler.GetUsage)
 	protected.GET("/users/:id", userHandler.GetUserByID)
 
+	// Board writes carry whole element arrays; cap them before they are read.
+	bodyLimit := middleware.BodyLimit(app.Config.Limits.MaxRequestSize)
+
 	boardHandler := handler.NewBoardHandler(app.Service.BoardService)
 	protected.GET("/boards", boardHandler.GetBoardsByUserID)
+	protected.GET("/boards/trash", boardHandler.GetTrash)
 	protected.GET("/boards/:id", boardHandler.GetBoard)
-	protected.POST("/boards", boardHandler.CreateBoard)
-	protected.PUT("/boards/:id", boardHandler.UpdateBoard)
+	protected.POST("/boards", bodyLimit, boardHandler.CreateBoard)
+	protected.PUT("/boards/:id", bodyLimit, boardHandler.UpdateBoard)
+	protected.PATCH("/boards/:id/elements", bodyLimit, boardHandler.PatchElements)
+	protected.DELETE("/boards/:id", boardHandler.DeleteBoard)
+	protected.POST("/boards/:id/restore", boardHandler.RestoreBoard)
+	protected.POST("/boards/:id/duplicate", bodyLimit, boardHandler.DuplicateBoard)
+
+	sessionHandler := handler.NewSessionHandler(app.Service.SessionService)
+	protected.GET("/boards/:id/session", sessionHandler.GetSession)
+	protected.POST("/boards/:id/session", sessionHandler.JoinSession)
+	protected.PATCH("/boards/:id/session", sessionHandler.UpdateSession)
+	protected.DELETE("/boards/:id/session", sessionHandler.LeaveSession)
+
+	boardRevisionHandler := handler.NewBoardRevisionHandler(app.Service.BoardRevisionService)
+	protected.GET("/boards/:id/revisions", boardRevisionHandler.ListRevisions)
+	protected.GET("/boards/:id/revisions/:rev", boardRevisionHandler.GetRevision)
+	protected.POST("/boards/:id/revisions/:rev/restore", boardRevisionHandler.RestoreRevision)
+
+	boardMemberHandler := handler.NewBoardMemberHandler(app.Service.BoardMemberService)
+	protected.GET("/boards/:id/members", boardMemberHandler.ListMembers)
+	protected.POST("/boards/:id/members", boardMemberHandler.AddMember)
+	protected.PATCH("/boards/:id/members/:userId", boardMemberHandler.UpdateMember)
+	protected.DELETE("/boards/:id/members/:userId", boardMemberHandler.RemoveMember)
+
+	protected.GET("/boards/:id/share-links", shareLinkHandler.ListShareLinks)
+	protected.POST("/boards/:id/share-links", shareLinkHandler.CreateShareLink)
+	protected.DELETE("/boards/:id/share-links/:linkId", shareLinkHandler.RevokeShareLink)
+
+	exportHandler := handler.NewExportHandler(app.Service.ExportService)
+	protected.GET("/boards/:id/export.svg", exportHandler.ExportSVG)
+	protected.GET("/boards/:id/export.png", exportHandler.ExportPNG)
+	protected.GET("/boards/:id/export.pdf", exportHandler.ExportPDF)
+	protected.GET("/boards/:id/export.mmd", exportHandler.ExportMermaid)
+	protected.GET("/boards/:id/export.dot", exportHandler.ExportDOT)
+
+	importHandler := handler.NewImportHandler(app.Service.ImportService)
+	protected.POST("/boards/:id/import/mermaid", bodyLimit, importHandler.ImportMermaid)
+
+	searchHandler := handler.NewSearchHandler(app.Service.SearchService)
+	protected.GET("/search", searchHandler.Search)
+
+	folderHandler := handler.NewFolderHandler(app.Service.FolderService)
+	protected.GET("/folders", folderHandler.ListFolders)
+	protected.POST("/folders", folderHandler.CreateFolder)
+	protected.PATCH("/folders/:id", folderHandler.UpdateFolder)
+	protected.DELETE("/folders/:id", folderHandler.DeleteFolder)
+	protected.PUT("/boards/:id/folder", folderHandler.MoveBoard)
+
+	fileHandler := handler.NewFileHandler(app.Service.FileService)
+	protected.GET("/boards/:id/files", fileHandler.ListFiles)
+	protected.PUT("/boards/:id/files/:fileId", middleware.BodyLimit(app.Config.Storage.MaxFileSize), fileHandler.UploadFile)
+	protected.GET("/boards/:id/files/:fileId", fileHandler.GetFile)
+
+	protected.GET("/boards/:id/thumbnail.png", thumbnailHandler.GetThumbnail)
+
+	tagHandler := handler.NewTagHandler(app.Service.TagService)
+	protected.GET("/tags", tagHandler.ListTags)
+	protected.POST("/boards/:id/tags", tagHandler.TagBoard)
+	protected.DELETE("/boards/:id/tags/:tag", tagHandler.UntagBoard)
+
+	templateHandler := handler.NewTemplateHandler(app.Service.TemplateService)
+	protected.GET("/templates", templateHandler.ListTemplates)
+	protected.GET("/templates/:id", templateHandler.GetTemplate)
+	protected.POST("/templates", templateHandler.CreateTemplate)
+	protected.DELETE("/templates/:id", templateHandler.DeleteTemplate)
 }
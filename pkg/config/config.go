@@ -1,8 +1,12 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"strconv"
+	"time"
 )
 
 type DBConfig struct {
@@ -28,6 +32,9 @@ type AppConfig struct {
 	Gemini   GeminiConfig
 	LLM      LLMConfig
 	Speech   SpeechConfig
+	Board    BoardConfig
+	Storage  StorageConfig
+	Limits   LimitsConfig
 	LogLevel string
 	Env      string
 }
@@ -39,9 +46,10 @@ type AuthConfig struct {
 
 
 type LiveKitConfig struct {
-	Host      string
-	APIKey    string
-	APISecret string
+	Host               string
+	APIKey             string
+	APISecret          string
+	SessionIdleTimeout time.Duration // How long a board's bot stays in an empty room
 }
 
 type AWSConfig struct {
@@ -49,6 +57,7 @@ type AWSConfig struct {
 	SecretKey string
 	Region    string
 	Bucket    string
+	Endpoint  string // S3-compatible endpoint; empty uses AWS
 }
 
 type GeminiConfig struct {
@@ -64,7 +73,36 @@ type LLMConfig struct {
 }
 
 type SpeechConfig struct {
-	Host string // gRPC host:port for Python speech service
+	Host         string // gRPC host:port for Python speech service
+	VoiceReplies bool   // Whether new sessions speak the bot's replies aloud
+}
+
+type BoardConfig struct {
+	RevisionLimit  int           // Revisions kept per board
+	RevisionMaxAge time.Duration // Older revisions are pruned; 0 disables age-based pruning
+	TrashRetention time.Duration // How long deleted boards stay restorable
+	PurgeInterval  time.Duration // How often expired boards are purged from the trash
+	SyncSaveDelay  time.Duration // How long live edits collect before they are saved
+	ThumbnailDelay time.Duration // How long a board must be idle before its thumbnail is redrawn
+}
+
+type StorageConfig struct {
+	Provider       string        // "local" or "s3"
+	LocalDir       string        // Root directory for the local provider
+	MaxFileSize    int64         // Largest accepted upload in bytes
+	FileGCGrace    time.Duration // Unreferenced files younger than this are kept
+	FileGCInterval time.Duration // How often unreferenced files are collected
+	// URLSigningKey signs URLs that load without a bearer token, such as
+	// board thumbnails. Servers sharing a database must share the key.
+	URLSigningKey []byte
+	SignedURLTTL  time.Duration // How long a signed URL stays valid, at least
+}
+
+type LimitsConfig struct {
+	MaxRequestSize   int64 // Largest accepted board write request body in bytes
+	MaxElements      int   // Elements per board, not counting deleted ones
+	MaxTextLength    int   // Characters of text per element
+	MaxBoardsPerUser int   // Boards a user may own outside the trash; 0 is unlimited
 }
 
 func getEnvOrDefault(key, defaultValue string) string {
@@ -74,6 +112,20 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBoolOrDefault(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func LoadConfig() (*AppConfig, error) {
 	portStr := os.Getenv("DB_PORT")
 	portInt, err := strconv.Atoi(portStr)
@@ -97,15 +149,17 @@ func LoadConfig() (*AppConfig, error) {
 			JwksURL: os.Getenv("JWKS_URL"),
 		},
 		LiveKit: LiveKitConfig{
-			Host:      os.Getenv("LK_HOST"),
-			APIKey:    os.Getenv("LK_API_KEY"),
-			APISecret: os.Getenv("LK_API_SECRET"),
+			Host:               os.Getenv("LK_HOST"),
+			APIKey:             os.Getenv("LK_API_KEY"),
+			APISecret:          os.Getenv("LK_API_SECRET"),
+			SessionIdleTimeout: time.Duration(getEnvIntOrDefault("LK_SESSION_IDLE_TIMEOUT_SECONDS", 60)) * time.Second,
 		},
 		AWS: AWSConfig{
 			AccessKey: os.Getenv("AWS_ACCESS_KEY"),
 			SecretKey: os.Getenv("AWS_SECRET_KEY"),
 			Region:    os.Getenv("AWS_REGION"),
 			Bucket:    os.Getenv("AWS_S3_BUCKET"),
+			Endpoint:  os.Getenv("AWS_S3_ENDPOINT"),
 		},
 		Gemini: GeminiConfig{
 			RealtimeModel: os.Getenv("GEMINI_REALTIME_MODEL"),
@@ -113,15 +167,48 @@ func LoadConfig() (*AppConfig, error) {
 			APIKey:        os.Getenv("GEMINI_API_KEY"),
 		},
 		Speech: SpeechConfig{
-			Host: getEnvOrDefault("SPEECH_SERVICE_HOST", "localhost:50051"),
+			Host:         getEnvOrDefault("SPEECH_SERVICE_HOST", "localhost:50051"),
+			VoiceReplies: getEnvBoolOrDefault("SPEECH_VOICE_REPLIES", true),
 		},
 		LLM: LLMConfig{
 			Provider: getEnvOrDefault("LLM_PROVIDER", "ollama"),
 			Host:     getEnvOrDefault("LLM_HOST", "http://localhost:11434"),
 			Model:    getEnvOrDefault("LLM_MODEL", "llama3.2"),
 		},
+		Board: BoardConfig{
+			RevisionLimit:  getEnvIntOrDefault("BOARD_REVISION_LIMIT", 50),
+			RevisionMaxAge: time.Duration(getEnvIntOrDefault("BOARD_REVISION_MAX_AGE_DAYS", 30)) * 24 * time.Hour,
+			TrashRetention: time.Duration(getEnvIntOrDefault("BOARD_TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
+			PurgeInterval:  time.Duration(getEnvIntOrDefault("BOARD_PURGE_INTERVAL_MINUTES", 60)) * time.Minute,
+			SyncSaveDelay:  time.Duration(getEnvIntOrDefault("BOARD_SYNC_SAVE_DELAY_MS", 2000)) * time.Millisecond,
+			ThumbnailDelay: time.Duration(getEnvIntOrDefault("BOARD_THUMBNAIL_DELAY_MS", 5000)) * time.Millisecond,
+		},
+		Storage: StorageConfig{
+			Provider:       getEnvOrDefault("STORAGE_PROVIDER", "local"),
+			LocalDir:       getEnvOrDefault("STORAGE_LOCAL_DIR", "data/files"),
+			MaxFileSize:    int64(getEnvIntOrDefault("STORAGE_MAX_FILE_SIZE_MB", 10)) << 20,
+			FileGCGrace:    time.Duration(getEnvIntOrDefault("STORAGE_FILE_GC_GRACE_HOURS", 24)) * time.Hour,
+			FileGCInterval: time.Duration(getEnvIntOrDefault("STORAGE_FILE_GC_INTERVAL_MINUTES", 60)) * time.Minute,
+			URLSigningKey:  []byte(os.Getenv("STORAGE_URL_SIGNING_KEY")),
+			SignedURLTTL:   time.Duration(getEnvIntOrDefault("STORAGE_SIGNED_URL_TTL_MINUTES", 60)) * time.Minute,
+		},
+		Limits: LimitsConfig{
+			MaxRequestSize:   int64(getEnvIntOrDefault("LIMIT_MAX_REQUEST_SIZE_MB", 20)) << 20,
+			MaxElements:      getEnvIntOrDefault("LIMIT_MAX_ELEMENTS", 20000),
+			MaxTextLength:    getEnvIntOrDefault("LIMIT_MAX_TEXT_LENGTH", 50000),
+			MaxBoardsPerUser: getEnvIntOrDefault("LIMIT_MAX_BOARDS_PER_USER", 500),
+		},
 		LogLevel: "info",
 		Env:      os.Getenv("APP_ENV"),
 	}
+	if len(config.Storage.URLSigningKey) == 0 {
+		// Good for a single server; URLs it signed stop working when it
+		// restarts.
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate URL signing key: %w", err)
+		}
+		config.Storage.URLSigningKey = []byte(hex.EncodeToString(key))
+	}
 	return config, nil
 }
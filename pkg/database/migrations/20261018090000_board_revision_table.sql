@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS "board_revision" (
+	id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+	board_id UUID NOT NULL,
+	revision INTEGER NOT NULL,
+	elements JSONB,
+	element_count INTEGER NOT NULL DEFAULT 0,
+	created_by VARCHAR(255),
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT board_revision_board_id_fkey FOREIGN KEY (board_id) REFERENCES "board"(id) ON DELETE CASCADE,
+	CONSTRAINT board_revision_created_by_fkey FOREIGN KEY (created_by) REFERENCES "user"(id) ON DELETE SET NULL,
+	CONSTRAINT board_revision_board_id_revision_unique UNIQUE (board_id, revision)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE "board_revision";
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS "board_member" (
+	board_id UUID NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	role VARCHAR(16) NOT NULL,
+	invited_by VARCHAR(255),
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT board_member_pkey PRIMARY KEY (board_id, user_id),
+	CONSTRAINT board_member_board_id_fkey FOREIGN KEY (board_id) REFERENCES "board"(id) ON DELETE CASCADE,
+	CONSTRAINT board_member_user_id_fkey FOREIGN KEY (user_id) REFERENCES "user"(id) ON DELETE CASCADE,
+	CONSTRAINT board_member_invited_by_fkey FOREIGN KEY (invited_by) REFERENCES "user"(id) ON DELETE SET NULL,
+	CONSTRAINT board_member_role_check CHECK (role IN ('owner', 'editor', 'viewer'))
+);
+CREATE INDEX IF NOT EXISTS board_member_user_id_idx ON "board_member" (user_id);
+INSERT INTO "board_member" (board_id, user_id, role)
+SELECT id, owner_id, 'owner' FROM "board"
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE "board_member";
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS "board_share_link" (
+	id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+	board_id UUID NOT NULL,
+	token VARCHAR(64) NOT NULL,
+	created_by VARCHAR(255),
+	expires_at TIMESTAMPTZ,
+	access_count BIGINT NOT NULL DEFAULT 0,
+	last_accessed_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT board_share_link_board_id_fkey FOREIGN KEY (board_id) REFERENCES "board"(id) ON DELETE CASCADE,
+	CONSTRAINT board_share_link_created_by_fkey FOREIGN KEY (created_by) REFERENCES "user"(id) ON DELETE SET NULL,
+	CONSTRAINT board_share_link_token_unique UNIQUE (token)
+);
+CREATE INDEX IF NOT EXISTS board_share_link_board_id_idx ON "board_share_link" (board_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE "board_share_link";
+-- +goose StatementEnd
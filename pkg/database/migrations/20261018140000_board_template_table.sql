@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS "board_template" (
+	id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+	name VARCHAR(255) NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	owner_id VARCHAR(255),
+	elements JSONB,
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT board_template_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES "user"(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS board_template_owner_id_idx ON "board_template" (owner_id);
+
+-- Built-in templates have no owner and are visible to everyone.
+INSERT INTO "board_template" (id, name, description, elements) VALUES
+(
+	'5f0c2a9e-7a51-4a57-9d61-3f2b8c1e0a01',
+	'Architecture diagram',
+	'Client, API, cache and database wired together.',
+	'[
+		{"id":"client","type":"rectangle","x":0,"y":120,"width":160,"height":80,"label":{"text":"Client"}},
+		{"id":"api","type":"rectangle","x":280,"y":120,"width":160,"height":80,"label":{"text":"API"}},
+		{"id":"cache","type":"diamond","x":560,"y":0,"width":160,"height":100,"label":{"text":"Cache"}},
+		{"id":"database","type":"ellipse","x":560,"y":200,"width":160,"height":100,"label":{"text":"Database"}},
+		{"id":"client-api","type":"arrow","x":160,"y":160,"width":120,"height":0,"start":{"id":"client"},"end":{"id":"api"}},
+		{"id":"api-cache","type":"arrow","x":440,"y":140,"width":120,"height":90,"start":{"id":"api"},"end":{"id":"cache"}},
+		{"id":"api-database","type":"arrow","x":440,"y":180,"width":120,"height":70,"start":{"id":"api"},"end":{"id":"database"}}
+	]'::jsonb
+),
+(
+	'5f0c2a9e-7a51-4a57-9d61-3f2b8c1e0a02',
+	'Retrospective',
+	'Three columns for what went well, what to improve and action items.',
+	'[
+		{"id":"went-well","type":"rectangle","x":0,"y":0,"width":300,"height":500,"backgroundColor":"#b2f2bb","label":{"text":"Went well","verticalAlign":"top"}},
+		{"id":"to-improve","type":"rectangle","x":340,"y":0,"width":300,"height":500,"backgroundColor":"#ffec99","label":{"text":"To improve","verticalAlign":"top"}},
+		{"id":"action-items","type":"rectangle","x":680,"y":0,"width":300,"height":500,"backgroundColor":"#a5d8ff","label":{"text":"Action items","verticalAlign":"top"}}
+	]'::jsonb
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE "board_template";
+-- +goose StatementEnd
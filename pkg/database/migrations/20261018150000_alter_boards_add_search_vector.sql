@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE board ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(jsonb_to_tsvector('english', jsonb_path_query_array(coalesce(elements, '[]'), '$[*] ? (!(@.isDeleted == true)).text'), '["string"]'), 'B') ||
+    setweight(jsonb_to_tsvector('english', jsonb_path_query_array(coalesce(elements, '[]'), '$[*] ? (!(@.isDeleted == true)).label.text'), '["string"]'), 'B') ||
+    setweight(jsonb_to_tsvector('english', jsonb_path_query_array(coalesce(elements, '[]'), '$[*] ? (!(@.isDeleted == true)).name'), '["string"]'), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS board_search_vector_idx ON board USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS board_search_vector_idx;
+ALTER TABLE board DROP COLUMN search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS "folder" (
+	id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
+	user_id VARCHAR(255) NOT NULL,
+	parent_id UUID,
+	name VARCHAR(255) NOT NULL,
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT folder_user_id_fkey FOREIGN KEY (user_id) REFERENCES "user"(id) ON DELETE CASCADE,
+	CONSTRAINT folder_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES "folder"(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS folder_user_id_idx ON "folder" (user_id, parent_id);
+
+-- Folders and tags are personal, so they live on the member row: each member
+-- files a shared board wherever they like.
+ALTER TABLE "board_member"
+	ADD COLUMN folder_id UUID,
+	ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+	ADD CONSTRAINT board_member_folder_id_fkey FOREIGN KEY (folder_id) REFERENCES "folder"(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS board_member_folder_id_idx ON "board_member" (folder_id);
+CREATE INDEX IF NOT EXISTS board_member_tags_idx ON "board_member" USING GIN (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE "board_member" DROP COLUMN tags, DROP COLUMN folder_id;
+DROP TABLE "folder";
+-- +goose StatementEnd
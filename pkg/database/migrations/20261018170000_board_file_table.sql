@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- board_id deliberately has no foreign key: when a board is purged its rows
+-- must outlive it until the stored objects have been deleted.
+CREATE TABLE IF NOT EXISTS "board_file" (
+	board_id UUID NOT NULL,
+	file_id VARCHAR(255) NOT NULL,
+	mime_type VARCHAR(255) NOT NULL,
+	size BIGINT NOT NULL,
+	storage_key TEXT NOT NULL,
+	created_by VARCHAR(255),
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	PRIMARY KEY (board_id, file_id),
+	CONSTRAINT board_file_created_by_fkey FOREIGN KEY (created_by) REFERENCES "user"(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS board_file_created_at_idx ON "board_file" (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE "board_file";
+-- +goose StatementEnd
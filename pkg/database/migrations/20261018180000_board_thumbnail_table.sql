@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- Like board_file, rows outlive a purged board until the stored image has
+-- been deleted, so board_id has no foreign key.
+CREATE TABLE IF NOT EXISTS "board_thumbnail" (
+	board_id UUID PRIMARY KEY NOT NULL,
+	storage_key TEXT NOT NULL,
+	version BIGINT NOT NULL,
+	updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE "board_thumbnail";
+-- +goose StatementEnd
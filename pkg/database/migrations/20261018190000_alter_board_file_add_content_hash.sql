@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- SHA-256 of the stored bytes, hex encoded. Files uploaded before it existed
+-- have none and are hashed from storage when needed.
+ALTER TABLE board_file ADD COLUMN content_hash VARCHAR(64);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE board_file DROP COLUMN content_hash;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+package excalidraw
+
+import "math"
+
+// Bounds returns the axis-aligned box around the live elements, ignoring
+// rotation. ok is false when there are none.
+func Bounds(elements []Element) (minX, minY, maxX, maxY float64, ok bool) {
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	extend := func(x, y float64) {
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+	for _, element := range elements {
+		if element.IsDeleted {
+			continue
+		}
+		ok = true
+		if len(element.Points) > 0 {
+			for _, p := range element.Points {
+				extend(element.X+p[0], element.Y+p[1])
+			}
+			continue
+		}
+		extend(element.X, element.Y)
+		extend(element.X+element.Width, element.Y+element.Height)
+	}
+	if !ok {
+		return 0, 0, 0, 0, false
+	}
+	return minX, minY, maxX, maxY, true
+}
+
+// Translate moves every element by (dx, dy) in place.
+func Translate(elements []Element, dx, dy float64) {
+	for i := range elements {
+		elements[i].X += dx
+		elements[i].Y += dy
+	}
+}
@@ -0,0 +1,108 @@
+package excalidraw
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+)
+
+const idAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz_-"
+
+// NewID returns a random element id in the same 21-character nanoid format
+// Excalidraw uses.
+func NewID() string {
+	b := make([]byte, 21)
+	max := big.NewInt(int64(len(idAlphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic("excalidraw: crypto/rand failed: " + err.Error())
+		}
+		b[i] = idAlphabet[n.Int64()]
+	}
+	return string(b)
+}
+
+// CloneElements deep-copies elements, giving every element and group a fresh
+// id from newID and rewriting every reference between them to match.
+// References to ids outside elements are left untouched.
+func CloneElements(elements []Element, newID func() string) []Element {
+	ids := make(map[string]string, len(elements))
+	for _, element := range elements {
+		if element.ID != "" {
+			ids[element.ID] = newID()
+		}
+	}
+	groups := make(map[string]string)
+	remap := func(id string) string {
+		if mapped, ok := ids[id]; ok {
+			return mapped
+		}
+		return id
+	}
+	remapPtr := func(id *string) *string {
+		if id == nil {
+			return nil
+		}
+		mapped := remap(*id)
+		return &mapped
+	}
+
+	out := make([]Element, len(elements))
+	for i, element := range elements {
+		clone := element
+		clone.ID = remap(element.ID)
+		clone.FrameID = remapPtr(element.FrameID)
+		clone.ContainerID = remapPtr(element.ContainerID)
+
+		if element.GroupIDs != nil {
+			clone.GroupIDs = make([]string, len(element.GroupIDs))
+			for j, group := range element.GroupIDs {
+				if _, ok := groups[group]; !ok {
+					groups[group] = newID()
+				}
+				clone.GroupIDs[j] = groups[group]
+			}
+		}
+		if element.BoundElements != nil {
+			clone.BoundElements = make([]BoundElement, len(element.BoundElements))
+			for j, bound := range element.BoundElements {
+				clone.BoundElements[j] = BoundElement{ID: remap(bound.ID), Type: bound.Type}
+			}
+		}
+		if element.StartBinding != nil {
+			binding := *element.StartBinding
+			binding.ElementID = remap(binding.ElementID)
+			clone.StartBinding = &binding
+		}
+		if element.EndBinding != nil {
+			binding := *element.EndBinding
+			binding.ElementID = remap(binding.ElementID)
+			clone.EndBinding = &binding
+		}
+		if element.Start != nil {
+			ref := *element.Start
+			ref.ID = remap(ref.ID)
+			clone.Start = &ref
+		}
+		if element.End != nil {
+			ref := *element.End
+			ref.ID = remap(ref.ID)
+			clone.End = &ref
+		}
+		if element.Points != nil {
+			clone.Points = append([]Point(nil), element.Points...)
+		}
+		if element.Pressures != nil {
+			clone.Pressures = append([]float64(nil), element.Pressures...)
+		}
+		if element.Extra != nil {
+			clone.Extra = make(map[string]json.RawMessage, len(element.Extra))
+			for key, value := range element.Extra {
+				clone.Extra[key] = value
+			}
+		}
+		out[i] = clone
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package excalidraw
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCloneElements(t *testing.T) {
+	container := "box"
+	frame := "frame"
+	elements := []Element{
+		{ID: "frame", Type: TypeFrame},
+		{ID: "box", Type: TypeRectangle, FrameID: &frame, GroupIDs: []string{"g1"}, BoundElements: []BoundElement{{ID: "label", Type: TypeText}, {ID: "arrow", Type: TypeArrow}}},
+		{ID: "label", Type: TypeText, ContainerID: &container, GroupIDs: []string{"g1"}},
+		{ID: "arrow", Type: TypeArrow, StartBinding: &Binding{ElementID: "box"}, End: &ElementRef{ID: "elsewhere"}, Points: []Point{{0, 0}, {10, 0}}},
+	}
+
+	n := 0
+	clones := CloneElements(elements, func() string {
+		n++
+		return fmt.Sprintf("new%d", n)
+	})
+
+	if len(clones) != len(elements) {
+		t.Fatalf("len = %d, want %d", len(clones), len(elements))
+	}
+	for i := range clones {
+		if clones[i].ID == elements[i].ID {
+			t.Errorf("element %d kept its id %q", i, clones[i].ID)
+		}
+	}
+	frameID, boxID, labelID, arrowID := clones[0].ID, clones[1].ID, clones[2].ID, clones[3].ID
+
+	if got := *clones[1].FrameID; got != frameID {
+		t.Errorf("box frameId = %q, want %q", got, frameID)
+	}
+	if got := clones[1].BoundElements; got[0].ID != labelID || got[1].ID != arrowID {
+		t.Errorf("box boundElements = %v, want [%s %s]", got, labelID, arrowID)
+	}
+	if got := *clones[2].ContainerID; got != boxID {
+		t.Errorf("label containerId = %q, want %q", got, boxID)
+	}
+	if got := clones[3].StartBinding.ElementID; got != boxID {
+		t.Errorf("arrow startBinding = %q, want %q", got, boxID)
+	}
+	if got := clones[3].End.ID; got != "elsewhere" {
+		t.Errorf("arrow end = %q, want unresolved id kept", got)
+	}
+	if clones[1].GroupIDs[0] == "g1" || clones[1].GroupIDs[0] != clones[2].GroupIDs[0] {
+		t.Errorf("group ids = %v, %v, want one shared fresh id", clones[1].GroupIDs, clones[2].GroupIDs)
+	}
+
+	clones[3].Points[0] = Point{5, 5}
+	clones[3].StartBinding.ElementID = "changed"
+	if elements[3].Points[0] != (Point{0, 0}) || elements[3].StartBinding.ElementID != "box" {
+		t.Errorf("CloneElements() shares state with its input")
+	}
+}
+
+func TestNewID(t *testing.T) {
+	id := NewID()
+	if len(id) != 21 {
+		t.Errorf("len(NewID()) = %d, want 21", len(id))
+	}
+	if NewID() == id {
+		t.Errorf("NewID() returned the same id twice")
+	}
+}
@@ -0,0 +1,70 @@
+package excalidraw
+
+// Diff lists the ids of elements that differ between two versions of a board.
+// Deleted elements (isDeleted) are treated as absent.
+type Diff struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
+}
+
+// CountLive returns the number of elements that are not marked as deleted.
+func CountLive(elements []Element) int {
+	count := 0
+	for _, element := range elements {
+		if !element.IsDeleted {
+			count++
+		}
+	}
+	return count
+}
+
+// DiffElements compares two element arrays. Added and modified ids follow the
+// order of to, removed ids the order of from.
+func DiffElements(from, to []Element) Diff {
+	diff := Diff{
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []string{},
+	}
+
+	before := make(map[string]*Element, len(from))
+	for i := range from {
+		if !from[i].IsDeleted {
+			before[from[i].ID] = &from[i]
+		}
+	}
+	after := make(map[string]bool, len(to))
+
+	for i := range to {
+		element := &to[i]
+		if element.IsDeleted {
+			continue
+		}
+		after[element.ID] = true
+		previous, ok := before[element.ID]
+		if !ok {
+			diff.Added = append(diff.Added, element.ID)
+			continue
+		}
+		if elementChanged(previous, element) {
+			diff.Modified = append(diff.Modified, element.ID)
+		}
+	}
+
+	for i := range from {
+		if !from[i].IsDeleted && !after[from[i].ID] {
+			diff.Removed = append(diff.Removed, from[i].ID)
+		}
+	}
+	return diff
+}
+
+// elementChanged prefers Excalidraw's version counter and falls back to a
+// structural comparison for skeleton elements that carry no version.
+func elementChanged(a, b *Element) bool {
+	if a.Version != 0 && b.Version != 0 {
+		return a.Version != b.Version || a.VersionNonce != b.VersionNonce
+	}
+	return !sameElement(a, b)
+}
@@ -0,0 +1,266 @@
+package excalidraw
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ElementType is the Excalidraw "type" discriminator of an element.
+type ElementType string
+
+const (
+	TypeRectangle ElementType = "rectangle"
+	TypeDiamond   ElementType = "diamond"
+	TypeEllipse   ElementType = "ellipse"
+	TypeArrow     ElementType = "arrow"
+	TypeLine      ElementType = "line"
+	TypeText      ElementType = "text"
+	TypeFreedraw  ElementType = "freedraw"
+	TypeImage     ElementType = "image"
+	TypeFrame     ElementType = "frame"
+	// TypeMagicFrame is a frame whose contents can be turned into code.
+	TypeMagicFrame ElementType = "magicframe"
+	TypeEmbeddable ElementType = "embeddable"
+	TypeIframe     ElementType = "iframe"
+)
+
+// Known reports whether t is one of the element types the server understands.
+func (t ElementType) Known() bool {
+	switch t {
+	case TypeRectangle, TypeDiamond, TypeEllipse, TypeArrow, TypeLine,
+		TypeText, TypeFreedraw, TypeImage, TypeFrame, TypeMagicFrame,
+		TypeEmbeddable, TypeIframe:
+		return true
+	}
+	return false
+}
+
+// IsFrame reports whether elements of type t can contain other elements
+// through their frameId.
+func (t ElementType) IsFrame() bool {
+	return t == TypeFrame || t == TypeMagicFrame
+}
+
+// IsLinear reports whether elements of type t are drawn from a list of points
+// and may carry start/end bindings.
+func (t ElementType) IsLinear() bool {
+	return t == TypeArrow || t == TypeLine
+}
+
+// IsShape reports whether elements of type t are closed shapes that can
+// contain bound text and be the target of arrow bindings.
+func (t ElementType) IsShape() bool {
+	return t == TypeRectangle || t == TypeDiamond || t == TypeEllipse
+}
+
+// Point is an [x, y] pair relative to the element origin.
+type Point [2]float64
+
+type Roundness struct {
+	Type  int      `json:"type"`
+	Value *float64 `json:"value,omitempty"`
+}
+
+// BoundElement is an entry of an element's boundElements list, pointing at a
+// bound text or an arrow attached to it.
+type BoundElement struct {
+	ID   string      `json:"id"`
+	Type ElementType `json:"type"`
+}
+
+// Binding attaches one end of a linear element to another element.
+type Binding struct {
+	ElementID  string  `json:"elementId"`
+	Focus      float64 `json:"focus"`
+	Gap        float64 `json:"gap"`
+	FixedPoint *Point  `json:"fixedPoint,omitempty"`
+}
+
+// ElementRef is the skeleton form of an arrow end ("start"/"end"), as produced
+// by the frontend before it is expanded by convertToExcalidrawElements.
+type ElementRef struct {
+	ID   string      `json:"id,omitempty"`
+	Type ElementType `json:"type,omitempty"`
+	Text string      `json:"text,omitempty"`
+}
+
+// Label is the skeleton form of a text bound to a shape or arrow.
+type Label struct {
+	Text          string  `json:"text"`
+	FontSize      float64 `json:"fontSize,omitempty"`
+	FontFamily    int     `json:"fontFamily,omitempty"`
+	StrokeColor   string  `json:"strokeColor,omitempty"`
+	TextAlign     string  `json:"textAlign,omitempty"`
+	VerticalAlign string  `json:"verticalAlign,omitempty"`
+}
+
+// Element is a single Excalidraw element. It covers both the full scene
+// representation and the skeleton representation stored by the frontend.
+// Fields the model does not know about are kept in Extra so that decoding and
+// re-encoding an element never drops data.
+type Element struct {
+	ID              string         `json:"id"`
+	Type            ElementType    `json:"type"`
+	X               float64        `json:"x"`
+	Y               float64        `json:"y"`
+	Width           float64        `json:"width"`
+	Height          float64        `json:"height"`
+	Angle           float64        `json:"angle,omitempty"`
+	StrokeColor     string         `json:"strokeColor,omitempty"`
+	BackgroundColor string         `json:"backgroundColor,omitempty"`
+	FillStyle       string         `json:"fillStyle,omitempty"`
+	StrokeWidth     float64        `json:"strokeWidth,omitempty"`
+	StrokeStyle     string         `json:"strokeStyle,omitempty"`
+	Roughness       *float64       `json:"roughness,omitempty"`
+	Opacity         *float64       `json:"opacity,omitempty"`
+	Roundness       *Roundness     `json:"roundness,omitempty"`
+	GroupIDs        []string       `json:"groupIds,omitempty"`
+	FrameID         *string        `json:"frameId,omitempty"`
+	BoundElements   []BoundElement `json:"boundElements,omitempty"`
+	Seed            int64          `json:"seed,omitempty"`
+	Version         int64          `json:"version,omitempty"`
+	VersionNonce    int64          `json:"versionNonce,omitempty"`
+	Updated         int64          `json:"updated,omitempty"`
+	IsDeleted       bool           `json:"isDeleted,omitempty"`
+	Locked          bool           `json:"locked,omitempty"`
+	Link            *string        `json:"link,omitempty"`
+
+	// text
+	Text          string  `json:"text,omitempty"`
+	OriginalText  string  `json:"originalText,omitempty"`
+	FontSize      float64 `json:"fontSize,omitempty"`
+	FontFamily    int     `json:"fontFamily,omitempty"`
+	TextAlign     string  `json:"textAlign,omitempty"`
+	VerticalAlign string  `json:"verticalAlign,omitempty"`
+	LineHeight    float64 `json:"lineHeight,omitempty"`
+	ContainerID   *string `json:"containerId,omitempty"`
+
+	// arrow, line and freedraw
+	Points         []Point     `json:"points,omitempty"`
+	StartBinding   *Binding    `json:"startBinding,omitempty"`
+	EndBinding     *Binding    `json:"endBinding,omitempty"`
+	StartArrowhead *string     `json:"startArrowhead,omitempty"`
+	EndArrowhead   *string     `json:"endArrowhead,omitempty"`
+	Start          *ElementRef `json:"start,omitempty"`
+	End            *ElementRef `json:"end,omitempty"`
+	Label          *Label      `json:"label,omitempty"`
+	Pressures      []float64   `json:"pressures,omitempty"`
+
+	// image
+	FileID *string     `json:"fileId,omitempty"`
+	Status string      `json:"status,omitempty"`
+	Scale  *[2]float64 `json:"scale,omitempty"`
+
+	// frame
+	Name *string `json:"name,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// elementFields has the same layout as Element without its JSON methods.
+type elementFields Element
+
+var knownKeys = func() map[string]struct{} {
+	keys := make(map[string]struct{})
+	t := reflect.TypeOf(elementFields{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[name] = struct{}{}
+		}
+	}
+	return keys
+}()
+
+func (e *Element) UnmarshalJSON(data []byte) error {
+	var fields elementFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key := range raw {
+		if _, ok := knownKeys[key]; ok {
+			delete(raw, key)
+		}
+	}
+	*e = Element(fields)
+	if len(raw) > 0 {
+		e.Extra = raw
+	}
+	return nil
+}
+
+func (e Element) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(elementFields(e))
+	if err != nil || len(e.Extra) == 0 {
+		return data, err
+	}
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	for key, value := range e.Extra {
+		if _, ok := out[key]; !ok {
+			out[key] = value
+		}
+	}
+	return json.Marshal(out)
+}
+
+// Parse decodes a stored elements array. A null or empty payload yields no
+// elements. Any decoding problem is reported as a *ValidationError so callers
+// can surface it the same way as a semantic one.
+func Parse(data json.RawMessage) ([]Element, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" || trimmed == "null" {
+		return nil, nil
+	}
+
+	verr := &ValidationError{}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		verr.add("", -1, "elements must be a JSON array")
+		return nil, verr
+	}
+
+	elements := make([]Element, 0, len(raws))
+	for i, raw := range raws {
+		var element Element
+		if err := json.Unmarshal(raw, &element); err != nil {
+			verr.add(elementIDOf(raw), i, fmt.Sprintf("malformed element: %v", err))
+			continue
+		}
+		elements = append(elements, element)
+	}
+	if len(verr.Issues) > 0 {
+		return nil, verr
+	}
+	return elements, nil
+}
+
+// Marshal encodes elements back into the stored representation.
+func Marshal(elements []Element) (json.RawMessage, error) {
+	if elements == nil {
+		elements = []Element{}
+	}
+	return json.Marshal(elements)
+}
+
+// elementIDOf extracts the id of an element that failed to decode, if any.
+func elementIDOf(raw json.RawMessage) string {
+	var probe struct {
+		ID any `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return ""
+	}
+	if id, ok := probe.ID.(string); ok {
+		return id
+	}
+	return ""
+}
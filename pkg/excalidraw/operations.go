@@ -0,0 +1,275 @@
+package excalidraw
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// OperationType is the kind of change an Operation makes.
+type OperationType string
+
+const (
+	OpAdd    OperationType = "add"
+	OpUpdate OperationType = "update"
+	OpDelete OperationType = "delete"
+)
+
+// Operation is a single element-level change keyed by element id. Add and
+// update carry the full element; delete may carry the version the client last
+// saw so that deleting an element someone else has since changed is refused.
+type Operation struct {
+	Op      OperationType `json:"op"`
+	ID      string        `json:"id"`
+	Element *Element      `json:"element,omitempty"`
+	Version int64         `json:"version,omitempty"`
+}
+
+// Rejection reasons reported back for operations that were not applied.
+const (
+	ReasonStale    = "stale"
+	ReasonExists   = "exists"
+	ReasonNotFound = "not_found"
+	ReasonInvalid  = "invalid"
+)
+
+// Rejection describes an operation that was not applied. Current is the
+// element as stored, when there is one, so the client can reconcile.
+type Rejection struct {
+	Op      OperationType `json:"op"`
+	ID      string        `json:"id"`
+	Reason  string        `json:"reason"`
+	Message string        `json:"message,omitempty"`
+	Current *Element      `json:"current,omitempty"`
+}
+
+// ApplyResult is the outcome of ApplyOperations.
+type ApplyResult struct {
+	Elements []Element
+	Applied  []string
+	Rejected []Rejection
+}
+
+// ApplyOperations applies ops in order to a copy of elements. Conflicts are
+// resolved by Supersedes. Deleting an element marks it and any text bound
+// inside it isDeleted, as Excalidraw does, so that clients still holding the
+// element take the deletion rather than restoring it; live elements that
+// referred to them drop the reference.
+func ApplyOperations(elements []Element, ops []Operation) ApplyResult {
+	result := ApplyResult{
+		Elements: append([]Element(nil), elements...),
+		Applied:  []string{},
+		Rejected: []Rejection{},
+	}
+
+	index := func(id string) int {
+		for i := range result.Elements {
+			if result.Elements[i].ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+	reject := func(op Operation, id string, reason string, message string, current *Element) {
+		rejection := Rejection{Op: op.Op, ID: id, Reason: reason, Message: message}
+		if current != nil {
+			copied := *current
+			rejection.Current = &copied
+		}
+		result.Rejected = append(result.Rejected, rejection)
+	}
+
+	for _, op := range ops {
+		id := op.ID
+		if id == "" && op.Element != nil {
+			id = op.Element.ID
+		}
+		if id == "" {
+			reject(op, id, ReasonInvalid, "operation has no element id", nil)
+			continue
+		}
+
+		switch op.Op {
+		case OpAdd, OpUpdate:
+			if op.Element == nil {
+				reject(op, id, ReasonInvalid, fmt.Sprintf("%s requires an element", op.Op), nil)
+				continue
+			}
+			incoming := *op.Element
+			if incoming.ID == "" {
+				incoming.ID = id
+			}
+			if incoming.ID != id {
+				reject(op, id, ReasonInvalid, fmt.Sprintf("element id %q does not match operation id", incoming.ID), nil)
+				continue
+			}
+
+			i := index(id)
+			if op.Op == OpAdd {
+				if i >= 0 {
+					reject(op, id, ReasonExists, "element already exists", &result.Elements[i])
+					continue
+				}
+				result.Elements = append(result.Elements, incoming)
+				result.Applied = append(result.Applied, id)
+				continue
+			}
+
+			if i < 0 {
+				reject(op, id, ReasonNotFound, "element does not exist", nil)
+				continue
+			}
+			if !Supersedes(&incoming, &result.Elements[i]) {
+				reject(op, id, ReasonStale, fmt.Sprintf("stored version %d supersedes %d", result.Elements[i].Version, incoming.Version), &result.Elements[i])
+				continue
+			}
+			result.Elements[i] = incoming
+			result.Applied = append(result.Applied, id)
+
+		case OpDelete:
+			i := index(id)
+			if i < 0 || result.Elements[i].IsDeleted {
+				reject(op, id, ReasonNotFound, "element does not exist", nil)
+				continue
+			}
+			if op.Version != 0 && result.Elements[i].Version > op.Version {
+				reject(op, id, ReasonStale, fmt.Sprintf("stored version %d is newer than %d", result.Elements[i].Version, op.Version), &result.Elements[i])
+				continue
+			}
+			DeleteElement(result.Elements, id, time.Now().UnixMilli())
+			result.Applied = append(result.Applied, id)
+
+		default:
+			reject(op, id, ReasonInvalid, fmt.Sprintf("unknown operation %q", op.Op), nil)
+		}
+	}
+	return result
+}
+
+// Supersedes reports whether incoming should replace current. The higher
+// version wins and, on equal versions, the lower versionNonce wins, as in
+// Excalidraw. Copies that share both, such as skeletons, which carry neither,
+// are ordered by their updated time. A copy that ties on all three only
+// replaces current if it is identical to it: the client is re-sending what is
+// stored.
+func Supersedes(incoming, current *Element) bool {
+	if incoming.Version != current.Version {
+		return incoming.Version > current.Version
+	}
+	if incoming.VersionNonce != current.VersionNonce {
+		return incoming.VersionNonce < current.VersionNonce
+	}
+	if incoming.Updated != current.Updated {
+		return incoming.Updated > current.Updated
+	}
+	return sameElement(incoming, current)
+}
+
+func sameElement(a, b *Element) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return bytes.Equal(aJSON, bJSON)
+}
+
+// Reconcile merges incoming elements into elements by id, keeping whichever
+// copy Supersedes the other. It returns the merged scene, with new elements
+// appended in arrival order, and the incoming elements that won; copies
+// identical to the ones they replace are not counted. Deletions travel as
+// elements with isDeleted set, as they do between Excalidraw clients.
+func Reconcile(elements, incoming []Element) (merged []Element, accepted []Element) {
+	merged = append([]Element(nil), elements...)
+	index := make(map[string]int, len(merged))
+	for i, element := range merged {
+		index[element.ID] = i
+	}
+	for _, element := range incoming {
+		if element.ID == "" {
+			continue
+		}
+		i, ok := index[element.ID]
+		if !ok {
+			index[element.ID] = len(merged)
+			merged = append(merged, element)
+			accepted = append(accepted, element)
+			continue
+		}
+		if Supersedes(&element, &merged[i]) && !sameElement(&element, &merged[i]) {
+			merged[i] = element
+			accepted = append(accepted, element)
+		}
+	}
+	return merged, accepted
+}
+
+// DeleteElement marks the element id and any text bound inside it isDeleted,
+// in place, and drops the references live elements hold to them. Every
+// element it changes gets a new version, updated at now.
+func DeleteElement(elements []Element, id string, now int64) {
+	removed := map[string]bool{id: true}
+	for _, element := range elements {
+		if element.ContainerID != nil && *element.ContainerID == id {
+			removed[element.ID] = true
+		}
+	}
+
+	for i := range elements {
+		element := &elements[i]
+		if removed[element.ID] {
+			element.IsDeleted = true
+			bumpVersion(element, now)
+			continue
+		}
+		if !element.IsDeleted && dropReferences(element, removed) {
+			bumpVersion(element, now)
+		}
+	}
+}
+
+func bumpVersion(element *Element, now int64) {
+	element.Version++
+	element.VersionNonce = rand.Int64N(math.MaxInt32)
+	element.Updated = now
+}
+
+// dropReferences removes element's references to removed elements and
+// reports whether it had any.
+func dropReferences(element *Element, removed map[string]bool) bool {
+	changed := false
+	if len(element.BoundElements) > 0 {
+		bound := make([]BoundElement, 0, len(element.BoundElements))
+		for _, b := range element.BoundElements {
+			if !removed[b.ID] {
+				bound = append(bound, b)
+			}
+		}
+		changed = len(bound) != len(element.BoundElements)
+		element.BoundElements = bound
+	}
+	if element.StartBinding != nil && removed[element.StartBinding.ElementID] {
+		element.StartBinding = nil
+		changed = true
+	}
+	if element.EndBinding != nil && removed[element.EndBinding.ElementID] {
+		element.EndBinding = nil
+		changed = true
+	}
+	if element.Start != nil && removed[element.Start.ID] {
+		element.Start = nil
+		changed = true
+	}
+	if element.End != nil && removed[element.End.ID] {
+		element.End = nil
+		changed = true
+	}
+	if element.FrameID != nil && removed[*element.FrameID] {
+		element.FrameID = nil
+		changed = true
+	}
+	return changed
+}
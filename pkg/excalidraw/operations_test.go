@@ -0,0 +1,234 @@
+package excalidraw
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestApplyOperations(t *testing.T) {
+	container := "box"
+	stored := []Element{
+		{ID: "box", Type: TypeRectangle, Version: 3, VersionNonce: 10, BoundElements: []BoundElement{{ID: "label", Type: TypeText}, {ID: "arrow", Type: TypeArrow}}},
+		{ID: "label", Type: TypeText, Version: 1, ContainerID: &container},
+		{ID: "arrow", Type: TypeArrow, Version: 2, StartBinding: &Binding{ElementID: "box"}},
+	}
+
+	tests := []struct {
+		name         string
+		ops          []Operation
+		wantApplied  []string
+		wantRejected []string
+		wantIDs      []string
+		wantDeleted  []string
+	}{
+		{
+			name:        "newer version wins",
+			ops:         []Operation{{Op: OpUpdate, ID: "box", Element: &Element{ID: "box", Type: TypeRectangle, Version: 4}}},
+			wantApplied: []string{"box"},
+			wantIDs:     []string{"box", "label", "arrow"},
+		},
+		{
+			name:         "older version is stale",
+			ops:          []Operation{{Op: OpUpdate, ID: "box", Element: &Element{ID: "box", Type: TypeRectangle, Version: 2}}},
+			wantApplied:  []string{},
+			wantRejected: []string{ReasonStale},
+			wantIDs:      []string{"box", "label", "arrow"},
+		},
+		{
+			name:         "equal version loses to lower nonce",
+			ops:          []Operation{{Op: OpUpdate, ID: "box", Element: &Element{ID: "box", Type: TypeRectangle, Version: 3, VersionNonce: 11}}},
+			wantApplied:  []string{},
+			wantRejected: []string{ReasonStale},
+			wantIDs:      []string{"box", "label", "arrow"},
+		},
+		{
+			name:         "add existing and update missing",
+			ops:          []Operation{{Op: OpAdd, Element: &Element{ID: "box", Type: TypeEllipse}}, {Op: OpUpdate, ID: "ghost", Element: &Element{Type: TypeEllipse}}},
+			wantApplied:  []string{},
+			wantRejected: []string{ReasonExists, ReasonNotFound},
+			wantIDs:      []string{"box", "label", "arrow"},
+		},
+		{
+			name:        "delete cascades to bound text and references",
+			ops:         []Operation{{Op: OpDelete, ID: "box"}, {Op: OpAdd, Element: &Element{ID: "new", Type: TypeEllipse}}},
+			wantApplied: []string{"box", "new"},
+			wantIDs:     []string{"box", "label", "arrow", "new"},
+			wantDeleted: []string{"box", "label"},
+		},
+		{
+			name:         "deleted element cannot be deleted again",
+			ops:          []Operation{{Op: OpDelete, ID: "label"}, {Op: OpDelete, ID: "label"}},
+			wantApplied:  []string{"label"},
+			wantRejected: []string{ReasonNotFound},
+			wantIDs:      []string{"box", "label", "arrow"},
+			wantDeleted:  []string{"label"},
+		},
+		{
+			name:         "delete of a newer element is stale",
+			ops:          []Operation{{Op: OpDelete, ID: "arrow", Version: 1}},
+			wantApplied:  []string{},
+			wantRejected: []string{ReasonStale},
+			wantIDs:      []string{"box", "label", "arrow"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ApplyOperations(stored, tt.ops)
+
+			if !reflect.DeepEqual(result.Applied, tt.wantApplied) {
+				t.Errorf("Applied = %v, want %v", result.Applied, tt.wantApplied)
+			}
+			reasons := []string{}
+			for _, rejection := range result.Rejected {
+				reasons = append(reasons, rejection.Reason)
+			}
+			if tt.wantRejected == nil {
+				tt.wantRejected = []string{}
+			}
+			if !reflect.DeepEqual(reasons, tt.wantRejected) {
+				t.Errorf("Rejected reasons = %v, want %v", reasons, tt.wantRejected)
+			}
+			ids := []string{}
+			for _, element := range result.Elements {
+				ids = append(ids, element.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("element ids = %v, want %v", ids, tt.wantIDs)
+			}
+			var deleted []string
+			for _, element := range result.Elements {
+				if element.IsDeleted {
+					deleted = append(deleted, element.ID)
+				}
+			}
+			if !reflect.DeepEqual(deleted, tt.wantDeleted) {
+				t.Errorf("deleted ids = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if err := Validate(result.Elements); err != nil {
+				t.Errorf("Validate() after apply = %v", err)
+			}
+		})
+	}
+
+	if len(stored) != 3 || stored[0].Version != 3 || stored[0].IsDeleted || len(stored[0].BoundElements) != 2 {
+		t.Errorf("ApplyOperations() modified its input")
+	}
+}
+
+func TestDeleteElement(t *testing.T) {
+	container := "box"
+	elements := []Element{
+		{ID: "box", Type: TypeRectangle, Version: 3, BoundElements: []BoundElement{{ID: "label", Type: TypeText}, {ID: "arrow", Type: TypeArrow}}},
+		{ID: "label", Type: TypeText, Version: 1, ContainerID: &container},
+		{ID: "arrow", Type: TypeArrow, Version: 2, StartBinding: &Binding{ElementID: "box"}},
+		{ID: "other", Type: TypeEllipse, Version: 7},
+	}
+
+	DeleteElement(elements, "box", 1000)
+
+	wantVersions := []int64{4, 2, 3, 7}
+	for i, element := range elements {
+		if element.Version != wantVersions[i] {
+			t.Errorf("%s version = %d, want %d", element.ID, element.Version, wantVersions[i])
+		}
+	}
+	if !elements[0].IsDeleted || !elements[1].IsDeleted || elements[2].IsDeleted {
+		t.Errorf("isDeleted = %v, %v, %v", elements[0].IsDeleted, elements[1].IsDeleted, elements[2].IsDeleted)
+	}
+	if elements[2].StartBinding != nil || elements[2].Updated != 1000 {
+		t.Errorf("arrow = %+v, want binding dropped at 1000", elements[2])
+	}
+}
+
+func TestSupersedes(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming Element
+		current  Element
+		want     bool
+	}{
+		{
+			name:     "higher version",
+			incoming: Element{ID: "a", Version: 2, VersionNonce: 9},
+			current:  Element{ID: "a", Version: 1, VersionNonce: 1},
+			want:     true,
+		},
+		{
+			name:     "lower version",
+			incoming: Element{ID: "a", Version: 1},
+			current:  Element{ID: "a", Version: 2},
+		},
+		{
+			name:     "equal version, lower nonce",
+			incoming: Element{ID: "a", Version: 2, VersionNonce: 1},
+			current:  Element{ID: "a", Version: 2, VersionNonce: 9},
+			want:     true,
+		},
+		{
+			name:     "re-sent copy",
+			incoming: Element{ID: "a", Version: 2, VersionNonce: 9, X: 5},
+			current:  Element{ID: "a", Version: 2, VersionNonce: 9, X: 5},
+			want:     true,
+		},
+		{
+			name:     "equal version and nonce, different content",
+			incoming: Element{ID: "a", Version: 2, VersionNonce: 9, X: 5},
+			current:  Element{ID: "a", Version: 2, VersionNonce: 9, X: 6},
+		},
+		{
+			name:     "stale skeleton",
+			incoming: Element{ID: "a", X: 5, Updated: 100},
+			current:  Element{ID: "a", X: 6, Updated: 200},
+		},
+		{
+			name:     "newer skeleton",
+			incoming: Element{ID: "a", X: 5, Updated: 300},
+			current:  Element{ID: "a", X: 6, Updated: 200},
+			want:     true,
+		},
+		{
+			name:     "skeletons without timestamps",
+			incoming: Element{ID: "a", X: 5},
+			current:  Element{ID: "a", X: 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Supersedes(&tt.incoming, &tt.current); got != tt.want {
+				t.Errorf("Supersedes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	stored := []Element{
+		{ID: "a", Type: TypeRectangle, Version: 3, VersionNonce: 10},
+		{ID: "b", Type: TypeEllipse, Version: 5, VersionNonce: 10},
+	}
+	incoming := []Element{
+		{ID: "a", Type: TypeRectangle, Version: 4, IsDeleted: true},
+		{ID: "b", Type: TypeEllipse, Version: 5, VersionNonce: 20},
+		{ID: "a", Type: TypeRectangle, Version: 4, IsDeleted: true},
+		{ID: "c", Type: TypeDiamond, Version: 1},
+		{ID: "c", Type: TypeDiamond, Version: 2},
+	}
+
+	merged, accepted := Reconcile(stored, incoming)
+
+	var acceptedIDs []string
+	for _, element := range accepted {
+		acceptedIDs = append(acceptedIDs, fmt.Sprintf("%s@%d", element.ID, element.Version))
+	}
+	if want := []string{"a@4", "c@1", "c@2"}; !reflect.DeepEqual(acceptedIDs, want) {
+		t.Errorf("accepted = %v, want %v", acceptedIDs, want)
+	}
+	if len(merged) != 3 || !merged[0].IsDeleted || merged[1].VersionNonce != 10 || merged[2].Version != 2 {
+		t.Errorf("merged = %+v", merged)
+	}
+	if stored[0].Version != 3 {
+		t.Errorf("Reconcile() modified its input")
+	}
+}
@@ -0,0 +1,186 @@
+package excalidraw
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Issue describes a single problem found in an elements array. Index is the
+// position of the offending element, or -1 when the payload as a whole is
+// invalid.
+type Issue struct {
+	ElementID string `json:"elementId,omitempty"`
+	Index     int    `json:"index"`
+	Message   string `json:"message"`
+}
+
+// ValidationError is returned when an elements array is malformed or
+// internally inconsistent.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Issues) == 1 {
+		return fmt.Sprintf("invalid elements: %s", e.Issues[0].Message)
+	}
+	return fmt.Sprintf("invalid elements: %d issues", len(e.Issues))
+}
+
+// ElementIDs returns the ids of the offending elements in the order they were
+// found, without duplicates.
+func (e *ValidationError) ElementIDs() []string {
+	ids := make([]string, 0, len(e.Issues))
+	seen := make(map[string]bool, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.ElementID == "" || seen[issue.ElementID] {
+			continue
+		}
+		seen[issue.ElementID] = true
+		ids = append(ids, issue.ElementID)
+	}
+	return ids
+}
+
+func (e *ValidationError) add(elementID string, index int, message string) {
+	e.Issues = append(e.Issues, Issue{
+		ElementID: elementID,
+		Index:     index,
+		Message:   message,
+	})
+}
+
+// Validate checks that every element has a unique id and a known type, and
+// that every reference between elements (bound elements, arrow bindings,
+// text containers and frames) points at an element present in the array.
+func Validate(elements []Element) error {
+	verr := &ValidationError{}
+
+	byID := make(map[string]*Element, len(elements))
+	for i := range elements {
+		element := &elements[i]
+		if element.ID == "" {
+			verr.add("", i, "element has no id")
+			continue
+		}
+		if _, ok := byID[element.ID]; ok {
+			verr.add(element.ID, i, "duplicate element id")
+			continue
+		}
+		byID[element.ID] = element
+	}
+
+	for i := range elements {
+		element := &elements[i]
+		if element.ID == "" {
+			continue
+		}
+		if !element.Type.Known() {
+			verr.add(element.ID, i, fmt.Sprintf("unknown element type %q", element.Type))
+		}
+
+		for _, bound := range element.BoundElements {
+			if _, ok := byID[bound.ID]; !ok {
+				verr.add(element.ID, i, fmt.Sprintf("bound element %q does not exist", bound.ID))
+			}
+		}
+
+		checkLinearEnd := func(end string, ref *ElementRef, binding *Binding) {
+			if ref == nil && binding == nil {
+				return
+			}
+			if !element.Type.IsLinear() {
+				verr.add(element.ID, i, fmt.Sprintf("%s binding on non-linear element", end))
+				return
+			}
+			if ref != nil && ref.ID != "" {
+				if _, ok := byID[ref.ID]; !ok {
+					verr.add(element.ID, i, fmt.Sprintf("%s element %q does not exist", end, ref.ID))
+				}
+			}
+			if binding != nil {
+				if _, ok := byID[binding.ElementID]; !ok {
+					verr.add(element.ID, i, fmt.Sprintf("%s binding element %q does not exist", end, binding.ElementID))
+				}
+			}
+		}
+		checkLinearEnd("start", element.Start, element.StartBinding)
+		checkLinearEnd("end", element.End, element.EndBinding)
+
+		if element.ContainerID != nil {
+			if element.Type != TypeText {
+				verr.add(element.ID, i, "containerId on non-text element")
+			} else if _, ok := byID[*element.ContainerID]; !ok {
+				verr.add(element.ID, i, fmt.Sprintf("container %q does not exist", *element.ContainerID))
+			}
+		}
+
+		if element.FrameID != nil {
+			frame, ok := byID[*element.FrameID]
+			if !ok {
+				verr.add(element.ID, i, fmt.Sprintf("frame %q does not exist", *element.FrameID))
+			} else if !frame.Type.IsFrame() {
+				verr.add(element.ID, i, fmt.Sprintf("frameId %q is not a frame", *element.FrameID))
+			}
+		}
+	}
+
+	if len(verr.Issues) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// Limits bounds the size of an elements array. Zero fields are not enforced.
+type Limits struct {
+	MaxElements   int
+	MaxTextLength int
+}
+
+// CheckLimits reports an array with more than MaxElements elements, and each
+// element carrying more than MaxTextLength characters of text, as a
+// ValidationError. Deleted elements are ignored so that a board over the
+// limit can always be trimmed.
+func CheckLimits(elements []Element, limits Limits) error {
+	verr := &ValidationError{}
+	live := 0
+	for _, element := range elements {
+		if !element.IsDeleted {
+			live++
+		}
+	}
+	if limits.MaxElements > 0 && live > limits.MaxElements {
+		verr.add("", -1, fmt.Sprintf("%d elements exceed the limit of %d", live, limits.MaxElements))
+	}
+	if limits.MaxTextLength > 0 {
+		for i, element := range elements {
+			if element.IsDeleted {
+				continue
+			}
+			length := max(utf8.RuneCountInString(element.Text), utf8.RuneCountInString(element.OriginalText))
+			if element.Label != nil {
+				length = max(length, utf8.RuneCountInString(element.Label.Text))
+			}
+			if length > limits.MaxTextLength {
+				verr.add(element.ID, i, fmt.Sprintf("text of %d characters exceeds the limit of %d", length, limits.MaxTextLength))
+			}
+		}
+	}
+
+	if len(verr.Issues) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// ParseAndValidate decodes an elements array and validates it in one step.
+func ParseAndValidate(data []byte) ([]Element, error) {
+	elements, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(elements); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
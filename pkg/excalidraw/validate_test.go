@@ -0,0 +1,193 @@
+package excalidraw
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseAndValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name:  "null payload",
+			input: `null`,
+		},
+		{
+			name: "skeleton arrow between shapes",
+			input: `[
+				{"id":"a","type":"rectangle","x":0,"y":0,"width":10,"height":10,"boundElements":[{"id":"c","type":"arrow"}]},
+				{"id":"b","type":"diamond","x":50,"y":0,"width":10,"height":10,"boundElements":[{"id":"c","type":"arrow"}]},
+				{"id":"c","type":"arrow","x":10,"y":5,"width":40,"height":0,"start":{"id":"a"},"end":{"id":"b"}}
+			]`,
+		},
+		{
+			name: "bound text in container",
+			input: `[
+				{"id":"box","type":"rectangle","x":0,"y":0,"width":10,"height":10,"boundElements":[{"id":"t","type":"text"}]},
+				{"id":"t","type":"text","x":0,"y":0,"width":10,"height":10,"text":"hi","containerId":"box"}
+			]`,
+		},
+		{
+			name: "embeds inside a magic frame",
+			input: `[
+				{"id":"f","type":"magicframe","x":0,"y":0,"width":100,"height":100},
+				{"id":"e","type":"embeddable","x":0,"y":0,"width":10,"height":10,"frameId":"f","link":"https://example.com"},
+				{"id":"i","type":"iframe","x":20,"y":0,"width":10,"height":10,"frameId":"f"}
+			]`,
+		},
+		{
+			name:    "not an array",
+			input:   `{"id":"a"}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed element",
+			input:   `[{"id":"a","type":"rectangle","x":"left"}]`,
+			wantIDs: []string{"a"},
+			wantErr: true,
+		},
+		{
+			name: "duplicate ids",
+			input: `[
+				{"id":"a","type":"rectangle","x":0,"y":0,"width":1,"height":1},
+				{"id":"a","type":"ellipse","x":0,"y":0,"width":1,"height":1}
+			]`,
+			wantIDs: []string{"a"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			input:   `[{"id":"a","type":"blob","x":0,"y":0,"width":1,"height":1}]`,
+			wantIDs: []string{"a"},
+			wantErr: true,
+		},
+		{
+			name: "dangling bindings",
+			input: `[
+				{"id":"a","type":"rectangle","x":0,"y":0,"width":1,"height":1,"boundElements":[{"id":"gone","type":"arrow"}]},
+				{"id":"b","type":"arrow","x":0,"y":0,"width":1,"height":1,"startBinding":{"elementId":"a","focus":0,"gap":1},"endBinding":{"elementId":"missing","focus":0,"gap":1}},
+				{"id":"c","type":"text","x":0,"y":0,"width":1,"height":1,"text":"x","containerId":"nope"}
+			]`,
+			wantIDs: []string{"a", "b", "c"},
+			wantErr: true,
+		},
+		{
+			name: "frame reference to non-frame",
+			input: `[
+				{"id":"a","type":"rectangle","x":0,"y":0,"width":1,"height":1},
+				{"id":"b","type":"ellipse","x":0,"y":0,"width":1,"height":1,"frameId":"a"}
+			]`,
+			wantIDs: []string{"b"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAndValidate([]byte(tt.input))
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("ParseAndValidate() error = %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("ParseAndValidate() error = %v, want *ValidationError", err)
+			}
+			if tt.wantIDs != nil && !reflect.DeepEqual(verr.ElementIDs(), tt.wantIDs) {
+				t.Errorf("ElementIDs() = %v, want %v", verr.ElementIDs(), tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	elements, err := Parse([]byte(`[
+		{"id":"a","type":"text","x":0,"y":0,"width":1,"height":1,"text":"héllo"},
+		{"id":"b","type":"rectangle","x":0,"y":0,"width":1,"height":1,"label":{"text":"hello world"}},
+		{"id":"c","type":"text","x":0,"y":0,"width":1,"height":1,"text":"hi","originalText":"hi there"},
+		{"id":"d","type":"text","x":0,"y":0,"width":1,"height":1,"text":"long gone","isDeleted":true}
+	]`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		limits     Limits
+		wantIssues []string
+	}{
+		{
+			name:   "unlimited",
+			limits: Limits{},
+		},
+		{
+			name:   "within limits",
+			limits: Limits{MaxElements: 3, MaxTextLength: 11},
+		},
+		{
+			name:       "too many elements",
+			limits:     Limits{MaxElements: 2},
+			wantIssues: []string{""},
+		},
+		{
+			name:       "text too long",
+			limits:     Limits{MaxTextLength: 5},
+			wantIssues: []string{"b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckLimits(elements, tt.limits)
+			if tt.wantIssues == nil {
+				if err != nil {
+					t.Fatalf("CheckLimits() error = %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("CheckLimits() error = %v, want *ValidationError", err)
+			}
+			var ids []string
+			for _, issue := range verr.Issues {
+				ids = append(ids, issue.ElementID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIssues) {
+				t.Errorf("issue element ids = %q, want %q", ids, tt.wantIssues)
+			}
+		})
+	}
+}
+
+func TestElementRoundTripKeepsUnknownFields(t *testing.T) {
+	input := `{"id":"a","type":"rectangle","x":1,"y":2,"width":3,"height":4,"roughness":0,"index":"a0","customData":{"k":"v"}}`
+
+	var element Element
+	if err := json.Unmarshal([]byte(input), &element); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if element.Roughness == nil || *element.Roughness != 0 {
+		t.Errorf("Roughness = %v, want explicit 0", element.Roughness)
+	}
+
+	out, err := json.Marshal(element)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got, want map[string]any
+	json.Unmarshal(out, &got)
+	json.Unmarshal([]byte(input), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %s, want %s", out, input)
+	}
+}
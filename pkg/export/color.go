@@ -0,0 +1,140 @@
+package export
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RGBA is a parsed color with components in [0, 1].
+type RGBA struct{ R, G, B, A float64 }
+
+// namedColors covers the CSS names that show up in Excalidraw scenes.
+var namedColors = map[string]string{
+	"black":   "#000000",
+	"white":   "#ffffff",
+	"red":     "#ff0000",
+	"green":   "#008000",
+	"blue":    "#0000ff",
+	"yellow":  "#ffff00",
+	"orange":  "#ffa500",
+	"purple":  "#800080",
+	"gray":    "#808080",
+	"grey":    "#808080",
+	"pink":    "#ffc0cb",
+	"cyan":    "#00ffff",
+	"magenta": "#ff00ff",
+}
+
+// ParseColor parses #rgb, #rgba, #rrggbb, #rrggbbaa and a few CSS color
+// names. "transparent" and unrecognised values report ok == false.
+func ParseColor(c string) (RGBA, bool) {
+	c = strings.ToLower(strings.TrimSpace(c))
+	if named, ok := namedColors[c]; ok {
+		c = named
+	}
+	if !strings.HasPrefix(c, "#") {
+		return RGBA{}, false
+	}
+	hex := c[1:]
+	if len(hex) == 3 || len(hex) == 4 {
+		expanded := make([]byte, 0, 2*len(hex))
+		for i := 0; i < len(hex); i++ {
+			expanded = append(expanded, hex[i], hex[i])
+		}
+		hex = string(expanded)
+	}
+	if len(hex) != 6 && len(hex) != 8 {
+		return RGBA{}, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return RGBA{}, false
+	}
+	if len(hex) == 6 {
+		v = v<<8 | 0xff
+	}
+	return RGBA{
+		R: float64(v>>24&0xff) / 255,
+		G: float64(v>>16&0xff) / 255,
+		B: float64(v>>8&0xff) / 255,
+		A: float64(v&0xff) / 255,
+	}, true
+}
+
+// Hex formats c as #rrggbb, dropping alpha.
+func (c RGBA) Hex() string {
+	to8 := func(v float64) int { return int(math.Round(math.Max(0, math.Min(1, v)) * 255)) }
+	return fmt.Sprintf("#%02x%02x%02x", to8(c.R), to8(c.G), to8(c.B))
+}
+
+// darkColor approximates Excalidraw's dark theme filter,
+// invert(93%) hue-rotate(180deg): lightness is inverted while hue is kept.
+func darkColor(c string) string {
+	rgba, ok := ParseColor(c)
+	if !ok {
+		return c
+	}
+	h, s, l := toHSL(rgba)
+	l = 0.07 + (1-l)*0.86
+	dark := fromHSL(h, s, l)
+	dark.A = rgba.A
+	return dark.Hex()
+}
+
+func toHSL(c RGBA) (h, s, l float64) {
+	max := math.Max(c.R, math.Max(c.G, c.B))
+	min := math.Min(c.R, math.Min(c.G, c.B))
+	l = (max + min) / 2
+	if max == min {
+		return 0, 0, l
+	}
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+	switch max {
+	case c.R:
+		h = (c.G - c.B) / d
+		if c.G < c.B {
+			h += 6
+		}
+	case c.G:
+		h = (c.B-c.R)/d + 2
+	default:
+		h = (c.R-c.G)/d + 4
+	}
+	return h / 6, s, l
+}
+
+func fromHSL(h, s, l float64) RGBA {
+	if s == 0 {
+		return RGBA{l, l, l, 1}
+	}
+	q := l * (1 + s)
+	if l >= 0.5 {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	hue := func(t float64) float64 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		switch {
+		case t < 1.0/6:
+			return p + (q-p)*6*t
+		case t < 0.5:
+			return q
+		case t < 2.0/3:
+			return p + (q-p)*(2.0/3-t)*6
+		}
+		return p
+	}
+	return RGBA{hue(h + 1.0/3), hue(h), hue(h - 1.0/3), 1}
+}
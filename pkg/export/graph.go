@@ -0,0 +1,326 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"draw/pkg/excalidraw"
+)
+
+// Skipped is an element a text export could not represent.
+type Skipped struct {
+	ElementID string
+	Type      excalidraw.ElementType
+	Reason    string
+}
+
+// maxSkippedComments caps how many skipped elements a text export lists in
+// its comments; the rest are only counted.
+const maxSkippedComments = 100
+
+// writeSkipped lists skipped elements and why, as comments starting with
+// prefix.
+func writeSkipped(out *bytes.Buffer, prefix string, skipped []Skipped) {
+	oneLine := strings.NewReplacer("\r", " ", "\n", " ")
+	for i, s := range skipped {
+		if i == maxSkippedComments {
+			fmt.Fprintf(out, "    %s ... and %d more skipped\n", prefix, len(skipped)-i)
+			return
+		}
+		fmt.Fprintf(out, "    %s skipped %s %s: %s\n", prefix, s.Type, oneLine.Replace(s.ElementID), s.Reason)
+	}
+}
+
+// graphShape is the outline of a graph node.
+type graphShape int
+
+const (
+	shapeBox graphShape = iota
+	shapeRoundedBox
+	shapeDiamond
+	shapeEllipse
+)
+
+type graphNode struct {
+	// ID is the generated identifier used in the output.
+	ID    string
+	Label string
+	Shape graphShape
+}
+
+type graphEdge struct {
+	From, To           string
+	Label              string
+	Dashed, Thick      bool
+	StartHead, EndHead bool
+}
+
+// graph is the box-and-arrow structure of a board.
+type graph struct {
+	// LeftToRight is set when edges mostly run horizontally.
+	LeftToRight bool
+	Nodes       []graphNode
+	Edges       []graphEdge
+	Skipped     []Skipped
+}
+
+// buildGraph treats shapes with bound text, or with arrows attached, as nodes
+// and arrows bound to nodes at both ends as edges. Everything else that is
+// visible is reported as skipped.
+func buildGraph(elements []excalidraw.Element) *graph {
+	g := &graph{}
+	byID := make(map[string]*excalidraw.Element, len(elements))
+	boundText := make(map[string]string)
+	for i := range elements {
+		element := &elements[i]
+		if element.IsDeleted {
+			continue
+		}
+		byID[element.ID] = element
+		if element.Type == excalidraw.TypeText && element.ContainerID != nil {
+			text := element.OriginalText
+			if text == "" {
+				text = element.Text
+			}
+			boundText[*element.ContainerID] = text
+		}
+	}
+	label := func(element *excalidraw.Element) string {
+		if text, ok := boundText[element.ID]; ok {
+			return text
+		}
+		if element.Label != nil {
+			return element.Label.Text
+		}
+		return ""
+	}
+
+	// Shapes become nodes when they carry text or an arrow is bound to them,
+	// so first collect what the arrows point at.
+	type end struct {
+		id  string
+		ref *excalidraw.ElementRef
+	}
+	arrowEnd := func(ref *excalidraw.ElementRef, binding *excalidraw.Binding) end {
+		if binding != nil {
+			return end{id: binding.ElementID}
+		}
+		if ref != nil && ref.ID != "" {
+			return end{id: ref.ID}
+		}
+		if ref != nil && ref.Type.IsShape() {
+			// A skeleton arrow can declare the shape it creates inline.
+			return end{ref: ref}
+		}
+		return end{}
+	}
+	connected := make(map[string]bool)
+	for _, element := range elements {
+		if element.IsDeleted || !element.Type.IsLinear() {
+			continue
+		}
+		for _, e := range []end{arrowEnd(element.Start, element.StartBinding), arrowEnd(element.End, element.EndBinding)} {
+			connected[e.id] = true
+		}
+	}
+
+	nodeIDs := make(map[string]string)
+	addNode := func(key, text string, shape graphShape) string {
+		id := "n" + strconv.Itoa(len(g.Nodes)+1)
+		nodeIDs[key] = id
+		g.Nodes = append(g.Nodes, graphNode{ID: id, Label: text, Shape: shape})
+		return id
+	}
+	shapeOf := func(t excalidraw.ElementType, rounded bool) graphShape {
+		switch t {
+		case excalidraw.TypeDiamond:
+			return shapeDiamond
+		case excalidraw.TypeEllipse:
+			return shapeEllipse
+		}
+		if rounded {
+			return shapeRoundedBox
+		}
+		return shapeBox
+	}
+	skip := func(element excalidraw.Element, reason string) {
+		g.Skipped = append(g.Skipped, Skipped{ElementID: element.ID, Type: element.Type, Reason: reason})
+	}
+
+	for _, element := range elements {
+		if element.IsDeleted || !element.Type.IsShape() {
+			continue
+		}
+		text := label(&element)
+		if text == "" && !connected[element.ID] {
+			skip(element, "shape has no text and no arrows")
+			continue
+		}
+		addNode(element.ID, text, shapeOf(element.Type, element.Roundness != nil))
+	}
+
+	var horizontal, vertical float64
+	for _, element := range elements {
+		if element.IsDeleted {
+			continue
+		}
+		switch {
+		case element.Type.IsShape():
+			continue
+		case element.Type == excalidraw.TypeText:
+			if element.ContainerID != nil && byID[*element.ContainerID] != nil {
+				continue
+			}
+			skip(element, "text is not bound to a shape")
+			continue
+		case !element.Type.IsLinear():
+			skip(element, fmt.Sprintf("%s elements have no graph equivalent", element.Type))
+			continue
+		}
+
+		resolve := func(e end, suffix string) (string, bool) {
+			if e.ref != nil {
+				return addNode(element.ID+suffix, e.ref.Text, shapeOf(e.ref.Type, false)), true
+			}
+			id, ok := nodeIDs[e.id]
+			return id, ok
+		}
+		from, fromOK := resolve(arrowEnd(element.Start, element.StartBinding), ":start")
+		to, toOK := resolve(arrowEnd(element.End, element.EndBinding), ":end")
+		if !fromOK || !toOK {
+			skip(element, "arrow is not bound to shapes at both ends")
+			continue
+		}
+
+		endHead := element.EndArrowhead != nil
+		if element.EndArrowhead == nil && element.Type == excalidraw.TypeArrow && element.Version == 0 {
+			// Skeleton arrows get an end arrowhead when none is given.
+			endHead = true
+		}
+		g.Edges = append(g.Edges, graphEdge{
+			From:      from,
+			To:        to,
+			Label:     label(&element),
+			Dashed:    element.StrokeStyle == "dashed" || element.StrokeStyle == "dotted",
+			Thick:     element.StrokeWidth >= 4,
+			StartHead: element.StartArrowhead != nil,
+			EndHead:   endHead,
+		})
+		if n := len(element.Points); n > 1 {
+			horizontal += math.Abs(element.Points[n-1][0] - element.Points[0][0])
+			vertical += math.Abs(element.Points[n-1][1] - element.Points[0][1])
+		}
+	}
+	g.LeftToRight = horizontal > vertical
+	return g
+}
+
+// Mermaid writes the board as a Mermaid flowchart. Elements that cannot be
+// represented are listed in comments and returned.
+func Mermaid(elements []excalidraw.Element) ([]byte, []Skipped) {
+	g := buildGraph(elements)
+	var out bytes.Buffer
+	direction := "TD"
+	if g.LeftToRight {
+		direction = "LR"
+	}
+	fmt.Fprintf(&out, "flowchart %s\n", direction)
+	for _, node := range g.Nodes {
+		open, close := "[", "]"
+		switch node.Shape {
+		case shapeRoundedBox:
+			open, close = "(", ")"
+		case shapeDiamond:
+			open, close = "{", "}"
+		case shapeEllipse:
+			open, close = "((", "))"
+		}
+		fmt.Fprintf(&out, "    %s%s\"%s\"%s\n", node.ID, open, mermaidText(node.Label), close)
+	}
+	for _, edge := range g.Edges {
+		line := "--"
+		switch {
+		case edge.Dashed:
+			line = "-.-"
+		case edge.Thick:
+			line = "=="
+		}
+		if !edge.EndHead && !edge.Dashed {
+			line += line[:1]
+		}
+		if edge.EndHead {
+			line += ">"
+		}
+		if edge.StartHead {
+			line = "<" + line
+		}
+		if edge.Label != "" {
+			line += "|\"" + mermaidText(edge.Label) + "\"|"
+		}
+		fmt.Fprintf(&out, "    %s %s %s\n", edge.From, line, edge.To)
+	}
+	writeSkipped(&out, "%%", g.Skipped)
+	return out.Bytes(), g.Skipped
+}
+
+func mermaidText(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", "<br/>").Replace(s)
+}
+
+// DOT writes the board as a Graphviz digraph. Elements that cannot be
+// represented are listed in comments and returned.
+func DOT(elements []excalidraw.Element) ([]byte, []Skipped) {
+	g := buildGraph(elements)
+	var out bytes.Buffer
+	out.WriteString("digraph board {\n")
+	if g.LeftToRight {
+		out.WriteString("    rankdir=LR;\n")
+	}
+	for _, node := range g.Nodes {
+		attrs := "shape=box"
+		switch node.Shape {
+		case shapeRoundedBox:
+			attrs = `shape=box, style="rounded"`
+		case shapeDiamond:
+			attrs = "shape=diamond"
+		case shapeEllipse:
+			attrs = "shape=ellipse"
+		}
+		fmt.Fprintf(&out, "    %s [label=%s, %s];\n", node.ID, dotText(node.Label), attrs)
+	}
+	for _, edge := range g.Edges {
+		var attrs []string
+		if edge.Label != "" {
+			attrs = append(attrs, "label="+dotText(edge.Label))
+		}
+		switch {
+		case edge.StartHead && edge.EndHead:
+			attrs = append(attrs, "dir=both")
+		case edge.StartHead:
+			attrs = append(attrs, "dir=back")
+		case !edge.EndHead:
+			attrs = append(attrs, "dir=none")
+		}
+		if edge.Dashed {
+			attrs = append(attrs, "style=dashed")
+		}
+		if edge.Thick {
+			attrs = append(attrs, "penwidth=2")
+		}
+		fmt.Fprintf(&out, "    %s -> %s", edge.From, edge.To)
+		if len(attrs) > 0 {
+			fmt.Fprintf(&out, " [%s]", strings.Join(attrs, ", "))
+		}
+		out.WriteString(";\n")
+	}
+	writeSkipped(&out, "//", g.Skipped)
+	out.WriteString("}\n")
+	return out.Bytes(), g.Skipped
+}
+
+func dotText(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"draw/pkg/excalidraw"
+)
+
+func TestGraphExports(t *testing.T) {
+	elements, err := excalidraw.ParseAndValidate([]byte(`[
+		{"id":"a","type":"rectangle","x":0,"y":0,"width":100,"height":60,"label":{"text":"Say \"hi\""}},
+		{"id":"b","type":"diamond","x":200,"y":0,"width":100,"height":60,"boundElements":[{"id":"bt","type":"text"}]},
+		{"id":"bt","type":"text","x":210,"y":20,"width":80,"height":20,"text":"Ok?","containerId":"b"},
+		{"id":"ab","type":"arrow","x":100,"y":30,"width":100,"height":0,"points":[[0,0],[100,0]],"start":{"id":"a"},"end":{"id":"b"},"label":{"text":"next"}},
+		{"id":"bc","type":"arrow","version":3,"x":300,"y":30,"width":100,"height":0,"points":[[0,0],[100,0]],"strokeStyle":"dashed","startBinding":{"elementId":"b","focus":0,"gap":1},"end":{"type":"ellipse","text":"Done"}},
+		{"id":"loose","type":"arrow","x":0,"y":200,"width":50,"height":0,"points":[[0,0],[50,0]],"start":{"id":"a"}},
+		{"id":"note","type":"text","x":0,"y":300,"width":50,"height":20,"text":"todo"},
+		{"id":"empty","type":"rectangle","x":0,"y":400,"width":10,"height":10},
+		{"id":"gone","type":"rectangle","x":0,"y":500,"width":10,"height":10,"label":{"text":"x"},"isDeleted":true}
+	]`))
+	if err != nil {
+		t.Fatalf("ParseAndValidate() = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		export func([]excalidraw.Element) ([]byte, []Skipped)
+		want   []string
+	}{
+		{
+			name:   "mermaid",
+			export: Mermaid,
+			want: []string{
+				"flowchart LR\n",
+				`n1["Say #quot;hi#quot;"]`,
+				`n2{"Ok?"}`,
+				`n3(("Done"))`,
+				`n1 -->|"next"| n2`,
+				`n2 -.- n3`,
+				"%% skipped text note: text is not bound to a shape",
+			},
+		},
+		{
+			name:   "dot",
+			export: DOT,
+			want: []string{
+				"rankdir=LR;",
+				`n1 [label="Say \"hi\"", shape=box];`,
+				`n3 [label="Done", shape=ellipse];`,
+				`n1 -> n2 [label="next"];`,
+				`n2 -> n3 [dir=none, style=dashed];`,
+				"// skipped arrow loose: arrow is not bound to shapes at both ends",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, skipped := tt.export(elements)
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("output does not contain %q:\n%s", want, out)
+				}
+			}
+			var ids []string
+			for _, s := range skipped {
+				ids = append(ids, s.ElementID)
+			}
+			if want := []string{"empty", "loose", "note"}; !reflect.DeepEqual(ids, want) {
+				t.Errorf("skipped = %v, want %v", ids, want)
+			}
+		})
+	}
+}
+
+func TestGraphExportsCapSkippedComments(t *testing.T) {
+	var elements []excalidraw.Element
+	for i := range maxSkippedComments + 5 {
+		elements = append(elements, excalidraw.Element{
+			ID:     fmt.Sprintf("note%d", i),
+			Type:   excalidraw.TypeText,
+			Width:  50,
+			Height: 20,
+			Text:   "todo",
+		})
+	}
+
+	out, skipped := Mermaid(elements)
+	if len(skipped) != maxSkippedComments+5 {
+		t.Fatalf("len(skipped) = %d, want %d", len(skipped), maxSkippedComments+5)
+	}
+	if got := strings.Count(string(out), "%% skipped "); got != maxSkippedComments {
+		t.Errorf("output lists %d skipped elements, want %d", got, maxSkippedComments)
+	}
+	if want := "%% ... and 5 more skipped"; !strings.Contains(string(out), want) {
+		t.Errorf("output does not contain %q", want)
+	}
+}
@@ -0,0 +1,89 @@
+package export
+
+import "math"
+
+// pathSegment is a move ('M'), line ('L') or quadratic curve ('Q').
+type pathSegment struct {
+	op   byte
+	ctrl Vec
+	to   Vec
+}
+
+// pathSegments turns the points of a path primitive into drawing commands.
+// A non-zero radius replaces each interior corner with a quadratic curve that
+// starts and ends radius away from the corner. Closing the path is left to
+// the caller.
+func pathSegments(points []Vec, closed bool, radius float64) []pathSegment {
+	n := len(points)
+	if n == 0 {
+		return nil
+	}
+	if radius <= 0 || n < 3 {
+		out := make([]pathSegment, n)
+		out[0] = pathSegment{op: 'M', to: points[0]}
+		for i := 1; i < n; i++ {
+			out[i] = pathSegment{op: 'L', to: points[i]}
+		}
+		return out
+	}
+
+	toward := func(from, to Vec, dist float64) Vec {
+		length := math.Hypot(to.X-from.X, to.Y-from.Y)
+		if length == 0 {
+			return from
+		}
+		t := math.Min(dist, length/2) / length
+		return Vec{from.X + (to.X-from.X)*t, from.Y + (to.Y-from.Y)*t}
+	}
+
+	var out []pathSegment
+	if !closed {
+		out = append(out, pathSegment{op: 'M', to: points[0]})
+		for i := 1; i < n-1; i++ {
+			out = append(out,
+				pathSegment{op: 'L', to: toward(points[i], points[i-1], radius)},
+				pathSegment{op: 'Q', ctrl: points[i], to: toward(points[i], points[i+1], radius)},
+			)
+		}
+		return append(out, pathSegment{op: 'L', to: points[n-1]})
+	}
+
+	for i := 0; i < n; i++ {
+		prev, corner, next := points[(i+n-1)%n], points[i], points[(i+1)%n]
+		start := toward(corner, prev, radius)
+		if i == 0 {
+			out = append(out, pathSegment{op: 'M', to: start})
+		} else {
+			out = append(out, pathSegment{op: 'L', to: start})
+		}
+		out = append(out, pathSegment{op: 'Q', ctrl: corner, to: toward(corner, next, radius)})
+	}
+	return out
+}
+
+// hatchLines returns the strokes of a hachure fill covering box, at the
+// Excalidraw hachure angle. Callers clip them to the filled shape.
+func hatchLines(box Rect, gap float64, cross bool) [][2]Vec {
+	angle := -41 * math.Pi / 180
+	center := Vec{box.X + box.Width/2, box.Y + box.Height/2}
+	reach := math.Hypot(box.Width, box.Height)/2 + gap
+
+	var lines [][2]Vec
+	angles := []float64{angle}
+	if cross {
+		angles = append(angles, angle+math.Pi/2)
+	}
+	for _, a := range angles {
+		for offset := -reach; offset <= reach; offset += gap {
+			from := rotate(Vec{center.X + offset, center.Y - reach}, center, a)
+			to := rotate(Vec{center.X + offset, center.Y + reach}, center, a)
+			lines = append(lines, [2]Vec{from, to})
+		}
+	}
+	return lines
+}
+
+// hatchGap is the spacing of hachure lines for a stroke width.
+func hatchGap(strokeWidth float64) float64 {
+	return math.Max(4, strokeWidth*4)
+}
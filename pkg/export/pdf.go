@@ -0,0 +1,220 @@
+package export
+
+import (
+	"bytes"
+	"math"
+
+	"draw/pkg/excalidraw"
+
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// PDF renders elements as a PDF document with one page per frame, or a
+// single page holding the whole board when it has no frames. Setting
+// opts.FrameID exports that frame alone. One scene unit is one point.
+func PDF(elements []excalidraw.Element, opts Options) ([]byte, error) {
+	frames := []string{opts.FrameID}
+	if opts.FrameID == "" {
+		frames = frameIDs(elements)
+	}
+	if len(frames) == 0 {
+		frames = []string{""}
+	}
+
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{UnitStr: "pt"})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddUTF8FontFromBytes("goregular", "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes("gomono", "", gomono.TTF)
+
+	for _, frameID := range frames {
+		pageOpts := opts
+		pageOpts.FrameID = frameID
+		scene, err := Layout(elements, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		page := &pdfPage{pdf: pdf, origin: Vec{scene.Bounds.X, scene.Bounds.Y}}
+		page.draw(scene)
+	}
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// frameIDs lists the frames of a board in element order.
+func frameIDs(elements []excalidraw.Element) []string {
+	var ids []string
+	for _, element := range elements {
+		if element.Type.IsFrame() && !element.IsDeleted {
+			ids = append(ids, element.ID)
+		}
+	}
+	return ids
+}
+
+// pdfPage draws a scene onto a new page whose top-left corner is origin.
+type pdfPage struct {
+	pdf    *gofpdf.Fpdf
+	origin Vec
+}
+
+func (pg *pdfPage) pt(v Vec) (float64, float64) {
+	return v.X - pg.origin.X, v.Y - pg.origin.Y
+}
+
+func (pg *pdfPage) draw(s *Scene) {
+	pdf := pg.pdf
+	b := s.Bounds
+	pdf.AddPageFormat("P", gofpdf.SizeType{Wd: math.Max(b.Width, 1), Ht: math.Max(b.Height, 1)})
+	if s.Background != "" {
+		if rgba, ok := ParseColor(s.Background); ok {
+			pdf.SetFillColor(channel(rgba.R), channel(rgba.G), channel(rgba.B))
+			pdf.Rect(0, 0, b.Width, b.Height, "F")
+		}
+	}
+	if s.Clip != nil {
+		x, y := pg.pt(Vec{s.Clip.X, s.Clip.Y})
+		pdf.ClipRect(x, y, s.Clip.Width, s.Clip.Height, false)
+		defer pdf.ClipEnd()
+	}
+	pdf.SetLineCapStyle("round")
+	pdf.SetLineJoinStyle("round")
+	for _, p := range s.Primitives {
+		pg.drawPrimitive(p)
+	}
+}
+
+// color sets the fill, stroke or text color along with the opacity it is
+// painted at.
+func (pg *pdfPage) color(c string, opacity float64, set func(r, g, b int)) bool {
+	rgba, ok := ParseColor(c)
+	if !ok {
+		return false
+	}
+	set(channel(rgba.R), channel(rgba.G), channel(rgba.B))
+	pg.pdf.SetAlpha(rgba.A*opacity, "Normal")
+	return true
+}
+
+func (pg *pdfPage) drawPrimitive(p Primitive) {
+	pdf := pg.pdf
+	if p.Rotation != 0 {
+		x, y := pg.pt(p.Center)
+		pdf.TransformBegin()
+		// gofpdf measures angles counter-clockwise; Excalidraw clockwise.
+		pdf.TransformRotate(-p.Rotation*180/math.Pi, x, y)
+		defer pdf.TransformEnd()
+	}
+	defer pdf.SetAlpha(1, "Normal")
+
+	if p.Kind == KindText {
+		pg.drawText(p)
+		return
+	}
+	if p.Kind == KindPath && len(p.Points) == 0 {
+		return
+	}
+
+	style := p.Style
+	trace := func(op string) {
+		if p.Kind == KindEllipse {
+			x, y := pg.pt(Vec{p.Box.X + p.Box.Width/2, p.Box.Y + p.Box.Height/2})
+			pdf.Ellipse(x, y, math.Abs(p.Box.Width)/2, math.Abs(p.Box.Height)/2, 0, op)
+			return
+		}
+		for _, segment := range pathSegments(p.Points, p.Closed, p.Radius) {
+			x, y := pg.pt(segment.to)
+			switch segment.op {
+			case 'M':
+				pdf.MoveTo(x, y)
+			case 'L':
+				pdf.LineTo(x, y)
+			case 'Q':
+				cx, cy := pg.pt(segment.ctrl)
+				pdf.CurveTo(cx, cy, x, y)
+			}
+		}
+		if p.Closed {
+			pdf.ClosePath()
+		}
+		pdf.DrawPath(op)
+	}
+
+	if style.Fill != "" && (p.Kind == KindEllipse || p.Closed) {
+		if style.FillStyle == "solid" {
+			if pg.color(style.Fill, style.Opacity, pdf.SetFillColor) {
+				trace("F")
+			}
+		} else {
+			pg.hatch(p)
+		}
+	}
+	if style.Stroke != "" && pg.color(style.Stroke, style.Opacity, pdf.SetDrawColor) {
+		pdf.SetLineWidth(style.StrokeWidth)
+		pdf.SetDashPattern(append([]float64{}, style.Dash...), 0)
+		trace("D")
+	}
+}
+
+// hatch draws a hachure or cross-hatch fill clipped to the primitive. Path
+// clipping ignores corner rounding.
+func (pg *pdfPage) hatch(p Primitive) {
+	pdf := pg.pdf
+	if !pg.color(p.Style.Fill, p.Style.Opacity, pdf.SetDrawColor) {
+		return
+	}
+	box := p.Box
+	if p.Kind == KindEllipse {
+		x, y := pg.pt(Vec{box.X + box.Width/2, box.Y + box.Height/2})
+		pdf.ClipEllipse(x, y, math.Abs(box.Width)/2, math.Abs(box.Height)/2, false)
+	} else {
+		box = Rect{}
+		points := make([]gofpdf.PointType, len(p.Points))
+		for i, point := range p.Points {
+			box = box.union(Rect{point.X, point.Y, 0, 0})
+			points[i].X, points[i].Y = pg.pt(point)
+		}
+		pdf.ClipPolygon(points, false)
+	}
+	defer pdf.ClipEnd()
+
+	pdf.SetLineWidth(math.Max(0.5, p.Style.StrokeWidth/2))
+	pdf.SetDashPattern([]float64{}, 0)
+	for _, line := range hatchLines(box, hatchGap(p.Style.StrokeWidth), p.Style.FillStyle == "cross-hatch") {
+		x1, y1 := pg.pt(line[0])
+		x2, y2 := pg.pt(line[1])
+		pdf.Line(x1, y1, x2, y2)
+	}
+}
+
+func (pg *pdfPage) drawText(p Primitive) {
+	pdf := pg.pdf
+	if !pg.color(p.Style.Fill, p.Style.Opacity, pdf.SetTextColor) {
+		return
+	}
+	family := "goregular"
+	if IsMonospace(p.FontFamily) {
+		family = "gomono"
+	}
+	pdf.SetFont(family, "", p.FontSize)
+	for i, line := range p.Lines {
+		x, y := pg.pt(Vec{p.Origin.X, p.Origin.Y + float64(i)*p.FontSize*p.LineHeight})
+		switch p.Align {
+		case "center":
+			x -= pdf.GetStringWidth(line) / 2
+		case "right":
+			x -= pdf.GetStringWidth(line)
+		}
+		pdf.Text(x, y, line)
+	}
+}
+
+func channel(v float64) int {
+	return int(math.Round(v * 255))
+}
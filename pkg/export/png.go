@@ -0,0 +1,261 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"sync"
+
+	"draw/pkg/excalidraw"
+
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// MaxPixels caps the size of a rendered PNG.
+const MaxPixels = 64 << 20
+
+// ErrTooLarge is returned when a PNG export would exceed MaxPixels.
+var ErrTooLarge = errors.New("export too large")
+
+// PNG renders elements as a PNG image, scaled by opts.Scale.
+func PNG(elements []excalidraw.Element, opts Options) ([]byte, error) {
+	scene, err := Layout(elements, opts)
+	if err != nil {
+		return nil, err
+	}
+	return scene.PNG(opts.Scale)
+}
+
+// Thumbnail renders elements as a PNG that fits within width by height
+// pixels. Small boards are drawn at 1x rather than enlarged.
+func Thumbnail(elements []excalidraw.Element, width, height int) ([]byte, error) {
+	scene, err := Layout(elements, DefaultOptions())
+	if err != nil {
+		return nil, err
+	}
+	scale := 1.0
+	if b := scene.Bounds; b.Width > 0 && b.Height > 0 {
+		scale = math.Min(scale, math.Min(float64(width)/b.Width, float64(height)/b.Height))
+	}
+	return scene.PNG(scale)
+}
+
+// PNG rasterises the scene. A scale of zero or less draws at 1x.
+func (s *Scene) PNG(scale float64) ([]byte, error) {
+	if scale <= 0 {
+		scale = 1
+	}
+	b := s.Bounds
+	width := int(math.Ceil(b.Width * scale))
+	height := int(math.Ceil(b.Height * scale))
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	if width*height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrTooLarge, width, height)
+	}
+
+	r := &rasterizer{dc: gg.NewContext(width, height), origin: Vec{b.X, b.Y}, scale: scale}
+	if s.Background != "" {
+		r.setColor(s.Background, 1)
+		r.dc.Clear()
+	}
+	if s.Clip != nil {
+		r.clip = r.rectMask(*s.Clip)
+		r.dc.SetMask(r.clip)
+	}
+	for _, p := range s.Primitives {
+		r.draw(p)
+	}
+
+	var out bytes.Buffer
+	if err := r.dc.EncodePNG(&out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// rasterizer draws primitives onto a gg context. Drawing happens in pixel
+// space: gg does not scale line widths, dashes or glyphs with its matrix, so
+// scene coordinates are converted by hand.
+type rasterizer struct {
+	dc     *gg.Context
+	origin Vec
+	scale  float64
+	// clip is the frame mask, restored after each hatch fill.
+	clip *image.Alpha
+}
+
+func (r *rasterizer) px(v Vec) Vec {
+	return Vec{(v.X - r.origin.X) * r.scale, (v.Y - r.origin.Y) * r.scale}
+}
+
+func (r *rasterizer) setColor(c string, opacity float64) bool {
+	rgba, ok := ParseColor(c)
+	if !ok {
+		return false
+	}
+	r.dc.SetRGBA(rgba.R, rgba.G, rgba.B, rgba.A*opacity)
+	return true
+}
+
+func (r *rasterizer) rectMask(rect Rect) *image.Alpha {
+	bounds := image.Rect(0, 0, r.dc.Width(), r.dc.Height())
+	mask := image.NewAlpha(bounds)
+	min := r.px(Vec{rect.X, rect.Y})
+	max := r.px(Vec{rect.X + rect.Width, rect.Y + rect.Height})
+	area := image.Rect(int(math.Floor(min.X)), int(math.Floor(min.Y)),
+		int(math.Ceil(max.X)), int(math.Ceil(max.Y))).Intersect(bounds)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			mask.Pix[mask.PixOffset(x, y)] = 0xff
+		}
+	}
+	return mask
+}
+
+func (r *rasterizer) restoreClip() {
+	if r.clip != nil {
+		r.dc.SetMask(r.clip)
+	} else {
+		r.dc.ResetClip()
+	}
+}
+
+func (r *rasterizer) draw(p Primitive) {
+	dc := r.dc
+	dc.Push()
+	defer dc.Pop()
+	if p.Rotation != 0 {
+		center := r.px(p.Center)
+		dc.RotateAbout(p.Rotation, center.X, center.Y)
+	}
+
+	if p.Kind == KindText {
+		r.drawText(p)
+		return
+	}
+
+	trace := func() {
+		dc.NewSubPath()
+		if p.Kind == KindEllipse {
+			center := r.px(Vec{p.Box.X + p.Box.Width/2, p.Box.Y + p.Box.Height/2})
+			dc.DrawEllipse(center.X, center.Y, math.Abs(p.Box.Width)/2*r.scale, math.Abs(p.Box.Height)/2*r.scale)
+			return
+		}
+		for _, segment := range pathSegments(p.Points, p.Closed, p.Radius) {
+			to := r.px(segment.to)
+			switch segment.op {
+			case 'M':
+				dc.MoveTo(to.X, to.Y)
+			case 'L':
+				dc.LineTo(to.X, to.Y)
+			case 'Q':
+				ctrl := r.px(segment.ctrl)
+				dc.QuadraticTo(ctrl.X, ctrl.Y, to.X, to.Y)
+			}
+		}
+		if p.Closed {
+			dc.ClosePath()
+		}
+	}
+	if p.Kind == KindPath && len(p.Points) == 0 {
+		return
+	}
+
+	style := p.Style
+	if style.Fill != "" && (p.Kind == KindEllipse || p.Closed) {
+		if style.FillStyle == "solid" {
+			if r.setColor(style.Fill, style.Opacity) {
+				trace()
+				dc.Fill()
+			}
+		} else {
+			r.hatch(p, trace)
+		}
+	}
+	if style.Stroke != "" && r.setColor(style.Stroke, style.Opacity) {
+		trace()
+		dc.SetLineWidth(style.StrokeWidth * r.scale)
+		dc.SetLineCap(gg.LineCapRound)
+		dc.SetLineJoin(gg.LineJoinRound)
+		dash := make([]float64, len(style.Dash))
+		for i, d := range style.Dash {
+			dash[i] = d * r.scale
+		}
+		dc.SetDash(dash...)
+		dc.Stroke()
+	}
+}
+
+// hatch draws a hachure or cross-hatch fill clipped to the traced shape.
+func (r *rasterizer) hatch(p Primitive, trace func()) {
+	if !r.setColor(p.Style.Fill, p.Style.Opacity) {
+		return
+	}
+	dc := r.dc
+	trace()
+	dc.Clip()
+	defer r.restoreClip()
+
+	box := p.Box
+	if p.Kind == KindPath {
+		box = Rect{}
+		for _, point := range p.Points {
+			box = box.union(Rect{point.X, point.Y, 0, 0})
+		}
+	}
+	dc.SetLineWidth(math.Max(0.5, p.Style.StrokeWidth/2) * r.scale)
+	dc.SetDash()
+	for _, line := range hatchLines(box, hatchGap(p.Style.StrokeWidth), p.Style.FillStyle == "cross-hatch") {
+		from, to := r.px(line[0]), r.px(line[1])
+		dc.DrawLine(from.X, from.Y, to.X, to.Y)
+		dc.Stroke()
+	}
+}
+
+func (r *rasterizer) drawText(p Primitive) {
+	if !r.setColor(p.Style.Fill, p.Style.Opacity) {
+		return
+	}
+	r.dc.SetFontFace(fontFace(IsMonospace(p.FontFamily), p.FontSize*r.scale))
+	anchor := 0.0
+	switch p.Align {
+	case "center":
+		anchor = 0.5
+	case "right":
+		anchor = 1
+	}
+	for i, line := range p.Lines {
+		origin := r.px(Vec{p.Origin.X, p.Origin.Y + float64(i)*p.FontSize*p.LineHeight})
+		r.dc.DrawStringAnchored(line, origin.X, origin.Y, anchor, 0)
+	}
+}
+
+var (
+	fontsOnce             sync.Once
+	regularFont, monoFont *truetype.Font
+)
+
+// fontFace returns a Go font face of the given pixel size. The Excalidraw
+// hand-drawn fonts are not bundled; Go Regular and Go Mono stand in for them.
+func fontFace(mono bool, size float64) font.Face {
+	fontsOnce.Do(func() {
+		regularFont, _ = truetype.Parse(goregular.TTF)
+		monoFont, _ = truetype.Parse(gomono.TTF)
+	})
+	f := regularFont
+	if mono {
+		f = monoFont
+	}
+	return truetype.NewFace(f, &truetype.Options{Size: size})
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"regexp"
+	"testing"
+
+	"draw/pkg/excalidraw"
+)
+
+func testElements(t *testing.T) []excalidraw.Element {
+	t.Helper()
+	elements, err := excalidraw.ParseAndValidate([]byte(`[
+		{"id":"one","type":"frame","x":0,"y":0,"width":200,"height":100,"name":"One"},
+		{"id":"two","type":"frame","x":300,"y":0,"width":200,"height":100,"name":"Two"},
+		{"id":"a","type":"rectangle","x":20,"y":20,"width":100,"height":60,"backgroundColor":"#a5d8ff","fillStyle":"hachure","frameId":"one","label":{"text":"Hello"}},
+		{"id":"b","type":"ellipse","x":320,"y":20,"width":100,"height":60,"angle":0.5,"backgroundColor":"#ffc9c9","fillStyle":"solid","frameId":"two"}
+	]`))
+	if err != nil {
+		t.Fatalf("ParseAndValidate() = %v", err)
+	}
+	return elements
+}
+
+func TestPNG(t *testing.T) {
+	elements := testElements(t)
+
+	tests := []struct {
+		name          string
+		opts          func(*Options)
+		width, height int
+		wantErr       error
+	}{
+		{name: "whole board", width: 520, height: 142},
+		{name: "scaled frame", opts: func(o *Options) { o.FrameID = "one"; o.Padding = 0; o.Scale = 2 }, width: 400, height: 200},
+		{name: "too large", opts: func(o *Options) { o.Scale = 1000 }, wantErr: ErrTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			if tt.opts != nil {
+				tt.opts(&opts)
+			}
+			out, err := PNG(elements, opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PNG() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			img, err := png.Decode(bytes.NewReader(out))
+			if err != nil {
+				t.Fatalf("png.Decode() = %v", err)
+			}
+			if size := img.Bounds().Size(); size.X != tt.width || size.Y != tt.height {
+				t.Errorf("size = %v, want %dx%d", size, tt.width, tt.height)
+			}
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	out, err := Thumbnail(testElements(t), 320, 200)
+	if err != nil {
+		t.Fatalf("Thumbnail() = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("png.Decode() = %v", err)
+	}
+	if size := img.Bounds().Size(); size.X != 320 || size.Y != 87 {
+		t.Errorf("size = %v, want 320x87", size)
+	}
+}
+
+func TestPDF(t *testing.T) {
+	elements := testElements(t)
+	pages := regexp.MustCompile(`/Type /Page\b`)
+
+	tests := []struct {
+		name      string
+		elements  []excalidraw.Element
+		frameID   string
+		wantPages int
+	}{
+		{name: "page per frame", elements: elements, wantPages: 2},
+		{name: "single frame", elements: elements, frameID: "two", wantPages: 1},
+		{name: "no frames", elements: elements[2:3], wantPages: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.FrameID = tt.frameID
+			out, err := PDF(tt.elements, opts)
+			if err != nil {
+				t.Fatalf("PDF() = %v", err)
+			}
+			if !bytes.HasPrefix(out, []byte("%PDF-")) {
+				t.Fatalf("output is not a PDF: %q", out[:min(len(out), 16)])
+			}
+			if got := len(pages.FindAll(out, -1)); got != tt.wantPages {
+				t.Errorf("pages = %d, want %d", got, tt.wantPages)
+			}
+		})
+	}
+}
@@ -0,0 +1,659 @@
+// Package export renders Excalidraw elements to static formats.
+//
+// Rendering happens in two steps: elements are first laid out into a Scene of
+// simple primitives (paths, ellipses and text runs in absolute coordinates),
+// which each output format then draws. Strokes are drawn clean; Excalidraw's
+// hand-drawn roughness is not reproduced.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"draw/pkg/excalidraw"
+)
+
+// Options controls how a board is exported.
+type Options struct {
+	// Background fills the canvas with BackgroundColor when set.
+	Background      bool
+	BackgroundColor string
+	// Padding is the margin, in scene units, around the exported content.
+	Padding float64
+	// DarkMode inverts the lightness of every color the way Excalidraw's
+	// dark theme does.
+	DarkMode bool
+	// FrameID limits the export to a single frame and the elements inside it.
+	FrameID string
+	// Scale multiplies the pixel size of raster exports.
+	Scale float64
+}
+
+// DefaultOptions returns the options used when a caller does not override
+// them.
+func DefaultOptions() Options {
+	return Options{
+		Background:      true,
+		BackgroundColor: "#ffffff",
+		Padding:         10,
+		Scale:           1,
+	}
+}
+
+// ErrFrameNotFound is returned when Options.FrameID names no frame.
+var ErrFrameNotFound = errors.New("frame not found")
+
+// Excalidraw defaults for fields skeleton elements usually leave out.
+const (
+	defaultStrokeColor = "#1e1e1e"
+	defaultStrokeWidth = 2
+	defaultFontSize    = 20
+	defaultFontFamily  = 5
+	defaultLineHeight  = 1.25
+	frameLabelSize     = 14
+	frameStrokeColor   = "#bbb"
+	frameLabelColor    = "#999999"
+	labelPadding       = 5
+)
+
+// fontFamilies maps Excalidraw font family ids to CSS font stacks.
+var fontFamilies = map[int]string{
+	1: "Virgil, Segoe UI Emoji",
+	2: "Helvetica, Segoe UI Emoji",
+	3: "Cascadia, Segoe UI Emoji",
+	5: "Excalifont, Xiaolai, Segoe UI Emoji",
+	6: "Nunito, Segoe UI Emoji",
+	7: "Lilita One, Segoe UI Emoji",
+	8: "Comic Shanns, Segoe UI Emoji",
+}
+
+// FontFamily returns the CSS font stack for an Excalidraw font family id.
+func FontFamily(id int) string {
+	if family, ok := fontFamilies[id]; ok {
+		return family
+	}
+	return fontFamilies[defaultFontFamily]
+}
+
+// IsMonospace reports whether an Excalidraw font family is monospaced.
+func IsMonospace(id int) bool {
+	return id == 3
+}
+
+// Vec is a point in absolute scene coordinates.
+type Vec struct{ X, Y float64 }
+
+// Rect is an axis-aligned box.
+type Rect struct{ X, Y, Width, Height float64 }
+
+func (r Rect) empty() bool { return r.Width == 0 && r.Height == 0 }
+
+func (r Rect) union(o Rect) Rect {
+	if r.empty() {
+		return o
+	}
+	minX, minY := math.Min(r.X, o.X), math.Min(r.Y, o.Y)
+	maxX := math.Max(r.X+r.Width, o.X+o.Width)
+	maxY := math.Max(r.Y+r.Height, o.Y+o.Height)
+	return Rect{minX, minY, maxX - minX, maxY - minY}
+}
+
+// Style is how a primitive is stroked and filled. Colors are empty when the
+// stroke or fill is not drawn.
+type Style struct {
+	Stroke      string
+	StrokeWidth float64
+	// Dash is the stroke dash pattern; nil draws a solid line.
+	Dash []float64
+	Fill string
+	// FillStyle is "solid", "hachure" or "cross-hatch".
+	FillStyle string
+	Opacity   float64
+}
+
+// Kind discriminates primitives.
+type Kind int
+
+const (
+	KindPath Kind = iota
+	KindEllipse
+	KindText
+)
+
+// Primitive is a single drawable item in absolute scene coordinates.
+type Primitive struct {
+	Kind  Kind
+	Style Style
+	// Rotation is in radians around Center.
+	Rotation float64
+	Center   Vec
+
+	// KindPath: Points joined by straight lines, or by quadratic corners of
+	// Radius when set. Closed paths can be filled.
+	Points []Vec
+	Closed bool
+	Radius float64
+
+	// KindEllipse: the ellipse inscribed in Box.
+	Box Rect
+
+	// KindText: Lines are laid out from Origin, which is the anchor point of
+	// the first baseline.
+	Lines      []string
+	Origin     Vec
+	FontSize   float64
+	FontFamily int
+	LineHeight float64
+	// Align is "left", "center" or "right".
+	Align string
+}
+
+// Scene is a laid-out board ready to be drawn.
+type Scene struct {
+	// Bounds is the exported area including padding.
+	Bounds     Rect
+	Background string
+	Primitives []Primitive
+	// Clip, when set, restricts drawing to a frame.
+	Clip *Rect
+}
+
+// Layout converts elements into a Scene. Deleted elements are skipped.
+func Layout(elements []excalidraw.Element, opts Options) (*Scene, error) {
+	color := func(c string) string { return c }
+	if opts.DarkMode {
+		color = darkColor
+	}
+
+	visible := make([]excalidraw.Element, 0, len(elements))
+	byID := make(map[string]*excalidraw.Element, len(elements))
+	for _, element := range elements {
+		if element.IsDeleted {
+			continue
+		}
+		visible = append(visible, element)
+	}
+	for i := range visible {
+		byID[visible[i].ID] = &visible[i]
+	}
+
+	scene := &Scene{}
+	if opts.FrameID != "" {
+		frame, ok := byID[opts.FrameID]
+		if !ok || !frame.Type.IsFrame() {
+			return nil, fmt.Errorf("%w: %q", ErrFrameNotFound, opts.FrameID)
+		}
+		inFrame := make([]excalidraw.Element, 0, len(visible))
+		for _, element := range visible {
+			if element.ID == frame.ID || inFrameOf(element, frame.ID, byID) {
+				inFrame = append(inFrame, element)
+			}
+		}
+		visible = inFrame
+		clip := Rect{frame.X, frame.Y, frame.Width, frame.Height}
+		scene.Clip = &clip
+	}
+
+	var bounds Rect
+	for _, element := range visible {
+		bounds = bounds.union(elementBounds(element))
+		scene.Primitives = append(scene.Primitives, layoutElement(element, color)...)
+	}
+	if scene.Clip != nil {
+		bounds = *scene.Clip
+	}
+	bounds.X -= opts.Padding
+	bounds.Y -= opts.Padding
+	bounds.Width += 2 * opts.Padding
+	bounds.Height += 2 * opts.Padding
+	scene.Bounds = bounds
+
+	if opts.Background {
+		background := opts.BackgroundColor
+		if background == "" {
+			background = "#ffffff"
+		}
+		scene.Background = color(background)
+	}
+	return scene, nil
+}
+
+// inFrameOf reports whether element belongs to the frame, either directly or
+// as text bound inside a container that does.
+func inFrameOf(element excalidraw.Element, frameID string, byID map[string]*excalidraw.Element) bool {
+	if element.FrameID != nil {
+		return *element.FrameID == frameID
+	}
+	if element.ContainerID != nil {
+		if container, ok := byID[*element.ContainerID]; ok && container.FrameID != nil {
+			return *container.FrameID == frameID
+		}
+	}
+	return false
+}
+
+// elementBounds returns the axis-aligned box an element covers on screen.
+func elementBounds(element excalidraw.Element) Rect {
+	var points []Vec
+	if element.Type.IsLinear() || element.Type == excalidraw.TypeFreedraw {
+		points = absolutePoints(element)
+	} else {
+		points = []Vec{
+			{element.X, element.Y},
+			{element.X + element.Width, element.Y},
+			{element.X + element.Width, element.Y + element.Height},
+			{element.X, element.Y + element.Height},
+		}
+	}
+	if element.Angle != 0 {
+		center := Vec{element.X + element.Width/2, element.Y + element.Height/2}
+		for i, p := range points {
+			points[i] = rotate(p, center, element.Angle)
+		}
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+		maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+	}
+	if element.Type.IsFrame() {
+		// Leave room for the frame name drawn above it.
+		minY -= frameLabelSize * 1.5
+	}
+	return Rect{minX, minY, maxX - minX, maxY - minY}
+}
+
+// absolutePoints returns the points of a linear element in scene coordinates.
+// Skeleton elements without points span their width and height.
+func absolutePoints(element excalidraw.Element) []Vec {
+	if len(element.Points) == 0 {
+		return []Vec{
+			{element.X, element.Y},
+			{element.X + element.Width, element.Y + element.Height},
+		}
+	}
+	points := make([]Vec, len(element.Points))
+	for i, p := range element.Points {
+		points[i] = Vec{element.X + p[0], element.Y + p[1]}
+	}
+	return points
+}
+
+func rotate(p, center Vec, angle float64) Vec {
+	sin, cos := math.Sincos(angle)
+	dx, dy := p.X-center.X, p.Y-center.Y
+	return Vec{center.X + dx*cos - dy*sin, center.Y + dx*sin + dy*cos}
+}
+
+func layoutElement(element excalidraw.Element, color func(string) string) []Primitive {
+	style := elementStyle(element, color)
+	box := Rect{element.X, element.Y, element.Width, element.Height}
+	center := Vec{element.X + element.Width/2, element.Y + element.Height/2}
+	base := Primitive{Style: style, Rotation: element.Angle, Center: center}
+
+	var out []Primitive
+	switch element.Type {
+	case excalidraw.TypeRectangle, excalidraw.TypeImage, excalidraw.TypeEmbeddable, excalidraw.TypeIframe:
+		p := base
+		p.Kind = KindPath
+		p.Points = corners(box)
+		p.Closed = true
+		p.Radius = cornerRadius(element)
+		if element.Type != excalidraw.TypeRectangle {
+			// Image data and embedded pages live outside the board, so draw a
+			// placeholder.
+			p.Style.Fill = color("#f1f3f5")
+			p.Style.FillStyle = "solid"
+			p.Style.Dash = []float64{8, 8}
+		}
+		out = append(out, p)
+	case excalidraw.TypeDiamond:
+		p := base
+		p.Kind = KindPath
+		p.Points = []Vec{
+			{box.X + box.Width/2, box.Y},
+			{box.X + box.Width, box.Y + box.Height/2},
+			{box.X + box.Width/2, box.Y + box.Height},
+			{box.X, box.Y + box.Height/2},
+		}
+		p.Closed = true
+		out = append(out, p)
+	case excalidraw.TypeEllipse:
+		p := base
+		p.Kind = KindEllipse
+		p.Box = box
+		out = append(out, p)
+	case excalidraw.TypeFrame, excalidraw.TypeMagicFrame:
+		p := base
+		p.Kind = KindPath
+		p.Points = corners(box)
+		p.Closed = true
+		p.Radius = 8
+		p.Style = Style{Stroke: color(frameStrokeColor), StrokeWidth: 1, Opacity: style.Opacity}
+		out = append(out, p)
+		name := "Frame"
+		if element.Name != nil {
+			name = *element.Name
+		}
+		out = append(out, Primitive{
+			Kind:       KindText,
+			Style:      Style{Fill: color(frameLabelColor), Opacity: style.Opacity},
+			Lines:      []string{name},
+			Origin:     Vec{box.X, box.Y - frameLabelSize*0.5},
+			FontSize:   frameLabelSize,
+			FontFamily: 2,
+			LineHeight: defaultLineHeight,
+			Align:      "left",
+		})
+	case excalidraw.TypeArrow, excalidraw.TypeLine:
+		points := absolutePoints(element)
+		p := base
+		p.Kind = KindPath
+		p.Points = points
+		p.Closed = element.Type == excalidraw.TypeLine && len(points) > 2 && points[0] == points[len(points)-1]
+		if !p.Closed {
+			p.Style.Fill = ""
+		}
+		if element.Roundness != nil && len(points) > 2 {
+			p.Radius = 16
+		}
+		out = append(out, p)
+		if element.Type == excalidraw.TypeArrow {
+			out = append(out, arrowheads(element, points, p)...)
+		}
+	case excalidraw.TypeFreedraw:
+		points := absolutePoints(element)
+		p := base
+		p.Kind = KindPath
+		p.Points = points
+		p.Style.Fill = ""
+		out = append(out, p)
+	case excalidraw.TypeText:
+		out = append(out, textPrimitive(element, base))
+	}
+
+	if element.Label != nil && element.Label.Text != "" {
+		out = append(out, labelPrimitive(element, color))
+	}
+	return out
+}
+
+func elementStyle(element excalidraw.Element, color func(string) string) Style {
+	style := Style{
+		Stroke:      element.StrokeColor,
+		StrokeWidth: element.StrokeWidth,
+		Fill:        element.BackgroundColor,
+		FillStyle:   element.FillStyle,
+		Opacity:     1,
+	}
+	if style.Stroke == "" {
+		style.Stroke = defaultStrokeColor
+	}
+	if style.StrokeWidth == 0 {
+		style.StrokeWidth = defaultStrokeWidth
+	}
+	if style.Fill == "transparent" {
+		style.Fill = ""
+	}
+	switch style.FillStyle {
+	case "solid", "cross-hatch":
+	case "":
+		// Skeleton shapes with a background default to a solid fill.
+		style.FillStyle = "solid"
+		if element.Version != 0 {
+			style.FillStyle = "hachure"
+		}
+	default:
+		style.FillStyle = "hachure"
+	}
+	switch element.StrokeStyle {
+	case "dashed":
+		style.Dash = []float64{8, 8 + style.StrokeWidth}
+	case "dotted":
+		style.Dash = []float64{1.5, 6 + style.StrokeWidth}
+	}
+	if element.Opacity != nil {
+		style.Opacity = math.Max(0, math.Min(100, *element.Opacity)) / 100
+	}
+	style.Stroke = color(style.Stroke)
+	if style.Fill != "" {
+		style.Fill = color(style.Fill)
+	}
+	return style
+}
+
+func corners(r Rect) []Vec {
+	return []Vec{
+		{r.X, r.Y},
+		{r.X + r.Width, r.Y},
+		{r.X + r.Width, r.Y + r.Height},
+		{r.X, r.Y + r.Height},
+	}
+}
+
+// cornerRadius follows Excalidraw: adaptive roundness (type 3) uses a fixed
+// radius capped by the shape size, legacy (type 2) is proportional.
+func cornerRadius(element excalidraw.Element) float64 {
+	if element.Roundness == nil {
+		return 0
+	}
+	size := math.Min(math.Abs(element.Width), math.Abs(element.Height))
+	if element.Roundness.Type == 3 {
+		radius := 32.0
+		if element.Roundness.Value != nil {
+			radius = *element.Roundness.Value
+		}
+		if size*0.25 < radius {
+			return size * 0.25
+		}
+		return radius
+	}
+	return size * 0.25
+}
+
+// arrowheads draws the heads at either end of an arrow. Skeleton arrows that
+// leave endArrowhead out get Excalidraw's default arrow; in full scene
+// elements a missing arrowhead means none.
+func arrowheads(element excalidraw.Element, points []Vec, line Primitive) []Primitive {
+	if len(points) < 2 {
+		return nil
+	}
+	var out []Primitive
+	end := element.EndArrowhead
+	if end == nil && element.Version == 0 {
+		def := "arrow"
+		end = &def
+	}
+	if end != nil {
+		out = append(out, arrowhead(*end, points[len(points)-2], points[len(points)-1], line)...)
+	}
+	if element.StartArrowhead != nil {
+		out = append(out, arrowhead(*element.StartArrowhead, points[1], points[0], line)...)
+	}
+	return out
+}
+
+// arrowhead draws one head of the given kind at tip, pointing away from from.
+func arrowhead(kind string, from, tip Vec, line Primitive) []Primitive {
+	dx, dy := tip.X-from.X, tip.Y-from.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return nil
+	}
+	ux, uy := dx/length, dy/length
+	size := math.Min(15+line.Style.StrokeWidth*2, length/2)
+
+	at := func(back, side float64) Vec {
+		return Vec{tip.X - ux*back - uy*side, tip.Y - uy*back + ux*side}
+	}
+	wing := size * math.Tan(25*math.Pi/180)
+
+	head := Primitive{
+		Kind:     KindPath,
+		Style:    line.Style,
+		Rotation: line.Rotation,
+		Center:   line.Center,
+	}
+	head.Style.Dash = nil
+	head.Style.FillStyle = "solid"
+
+	switch kind {
+	case "arrow":
+		head.Points = []Vec{at(size, wing), tip, at(size, -wing)}
+		head.Style.Fill = ""
+	case "bar":
+		head.Points = []Vec{at(0, size/2), at(0, -size/2)}
+		head.Style.Fill = ""
+	case "triangle", "triangle_outline":
+		head.Points = []Vec{tip, at(size, wing), at(size, -wing)}
+		head.Closed = true
+		head.Style.Fill = head.Style.Stroke
+		if strings.HasSuffix(kind, "_outline") {
+			head.Style.Fill = ""
+		}
+	case "diamond", "diamond_outline":
+		head.Points = []Vec{tip, at(size/2, wing/1.5), at(size, 0), at(size/2, -wing/1.5)}
+		head.Closed = true
+		head.Style.Fill = head.Style.Stroke
+		if strings.HasSuffix(kind, "_outline") {
+			head.Style.Fill = ""
+		}
+	case "dot", "circle", "circle_outline":
+		r := size / 3
+		c := at(r, 0)
+		head.Kind = KindEllipse
+		head.Box = Rect{c.X - r, c.Y - r, 2 * r, 2 * r}
+		head.Style.Fill = head.Style.Stroke
+		if kind == "circle_outline" {
+			head.Style.Fill = ""
+		}
+	default:
+		return nil
+	}
+	return []Primitive{head}
+}
+
+func textPrimitive(element excalidraw.Element, base Primitive) Primitive {
+	p := base
+	p.Kind = KindText
+	p.Style = Style{Fill: base.Style.Stroke, Opacity: base.Style.Opacity}
+	p.Lines = strings.Split(element.Text, "\n")
+	p.FontSize = orDefault(element.FontSize, defaultFontSize)
+	p.FontFamily = element.FontFamily
+	if p.FontFamily == 0 {
+		p.FontFamily = defaultFontFamily
+	}
+	p.LineHeight = orDefault(element.LineHeight, defaultLineHeight)
+	p.Align = element.TextAlign
+	if p.Align == "" {
+		p.Align = "left"
+	}
+
+	x := element.X
+	switch p.Align {
+	case "center":
+		x += element.Width / 2
+	case "right":
+		x += element.Width
+	}
+	p.Origin = Vec{x, element.Y + firstBaseline(p.FontSize, p.LineHeight)}
+	return p
+}
+
+// labelPrimitive lays out the skeleton label of a shape or arrow inside its
+// container.
+func labelPrimitive(element excalidraw.Element, color func(string) string) Primitive {
+	label := element.Label
+	p := Primitive{
+		Kind:       KindText,
+		Lines:      strings.Split(label.Text, "\n"),
+		FontSize:   orDefault(label.FontSize, defaultFontSize),
+		FontFamily: label.FontFamily,
+		LineHeight: defaultLineHeight,
+		Align:      label.TextAlign,
+		Rotation:   element.Angle,
+		Center:     Vec{element.X + element.Width/2, element.Y + element.Height/2},
+	}
+	if p.FontFamily == 0 {
+		p.FontFamily = defaultFontFamily
+	}
+	if p.Align == "" {
+		p.Align = "center"
+	}
+	stroke := label.StrokeColor
+	if stroke == "" {
+		stroke = element.StrokeColor
+	}
+	if stroke == "" {
+		stroke = defaultStrokeColor
+	}
+	p.Style = Style{Fill: color(stroke), Opacity: 1}
+	if element.Opacity != nil {
+		p.Style.Opacity = *element.Opacity / 100
+	}
+
+	blockHeight := float64(len(p.Lines)) * p.FontSize * p.LineHeight
+	var box Rect
+	if element.Type.IsLinear() {
+		mid := midpoint(absolutePoints(element))
+		box = Rect{mid.X, mid.Y - blockHeight/2, 0, blockHeight}
+		p.Align = "center"
+	} else {
+		box = Rect{element.X + labelPadding, element.Y, element.Width - 2*labelPadding, element.Height}
+	}
+
+	x := box.X
+	switch p.Align {
+	case "center":
+		x += box.Width / 2
+	case "right":
+		x += box.Width
+	}
+	top := box.Y + (box.Height-blockHeight)/2
+	switch label.VerticalAlign {
+	case "top":
+		top = box.Y + labelPadding
+	case "bottom":
+		top = box.Y + box.Height - blockHeight - labelPadding
+	}
+	p.Origin = Vec{x, top + firstBaseline(p.FontSize, p.LineHeight)}
+	return p
+}
+
+// firstBaseline is the distance from the top of a text block to the baseline
+// of its first line.
+func firstBaseline(fontSize, lineHeight float64) float64 {
+	return fontSize*lineHeight/2 + fontSize*0.35
+}
+
+// midpoint returns the point halfway along a polyline.
+func midpoint(points []Vec) Vec {
+	total := 0.0
+	for i := 1; i < len(points); i++ {
+		total += math.Hypot(points[i].X-points[i-1].X, points[i].Y-points[i-1].Y)
+	}
+	half := total / 2
+	for i := 1; i < len(points); i++ {
+		segment := math.Hypot(points[i].X-points[i-1].X, points[i].Y-points[i-1].Y)
+		if segment >= half && segment > 0 {
+			t := half / segment
+			return Vec{
+				points[i-1].X + (points[i].X-points[i-1].X)*t,
+				points[i-1].Y + (points[i].Y-points[i-1].Y)*t,
+			}
+		}
+		half -= segment
+	}
+	return points[0]
+}
+
+func orDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
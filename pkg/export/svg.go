@@ -0,0 +1,182 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"draw/pkg/excalidraw"
+)
+
+// SVG renders elements as a standalone SVG document.
+func SVG(elements []excalidraw.Element, opts Options) ([]byte, error) {
+	scene, err := Layout(elements, opts)
+	if err != nil {
+		return nil, err
+	}
+	return scene.SVG(), nil
+}
+
+// SVG draws the scene as a standalone SVG document.
+func (s *Scene) SVG() []byte {
+	var body bytes.Buffer
+	patterns := map[string]string{}
+	var defs bytes.Buffer
+
+	fill := func(style Style) string {
+		if style.Fill == "" {
+			return "none"
+		}
+		if style.FillStyle == "solid" {
+			return style.Fill
+		}
+		key := style.FillStyle + style.Fill
+		id, ok := patterns[key]
+		if !ok {
+			id = fmt.Sprintf("fill-%d", len(patterns))
+			patterns[key] = id
+			writeHatchPattern(&defs, id, style)
+		}
+		return "url(#" + id + ")"
+	}
+
+	for _, p := range s.Primitives {
+		attrs := transformAttrs(p)
+		switch p.Kind {
+		case KindPath:
+			if len(p.Points) == 0 {
+				continue
+			}
+			fmt.Fprintf(&body, `<path d="%s" fill="%s"%s%s/>`+"\n",
+				pathData(p.Points, p.Closed, p.Radius), fill(p.Style), strokeAttrs(p.Style), attrs)
+		case KindEllipse:
+			fmt.Fprintf(&body, `<ellipse cx="%s" cy="%s" rx="%s" ry="%s" fill="%s"%s%s/>`+"\n",
+				num(p.Box.X+p.Box.Width/2), num(p.Box.Y+p.Box.Height/2),
+				num(math.Abs(p.Box.Width)/2), num(math.Abs(p.Box.Height)/2),
+				fill(p.Style), strokeAttrs(p.Style), attrs)
+		case KindText:
+			writeText(&body, p, attrs)
+		}
+	}
+
+	b := s.Bounds
+	var out bytes.Buffer
+	fmt.Fprintf(&out, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%s" height="%s" viewBox="%s %s %s %s">`+"\n",
+		num(b.Width), num(b.Height), num(b.X), num(b.Y), num(b.Width), num(b.Height))
+	if s.Clip != nil {
+		fmt.Fprintf(&defs, `<clipPath id="frame-clip"><rect x="%s" y="%s" width="%s" height="%s"/></clipPath>`+"\n",
+			num(s.Clip.X), num(s.Clip.Y), num(s.Clip.Width), num(s.Clip.Height))
+	}
+	if defs.Len() > 0 {
+		out.WriteString("<defs>\n")
+		out.Write(defs.Bytes())
+		out.WriteString("</defs>\n")
+	}
+	if s.Background != "" {
+		fmt.Fprintf(&out, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+			num(b.X), num(b.Y), num(b.Width), num(b.Height), escapeAttr(s.Background))
+	}
+	if s.Clip != nil {
+		out.WriteString(`<g clip-path="url(#frame-clip)">` + "\n")
+	}
+	out.Write(body.Bytes())
+	if s.Clip != nil {
+		out.WriteString("</g>\n")
+	}
+	out.WriteString("</svg>\n")
+	return out.Bytes()
+}
+
+func transformAttrs(p Primitive) string {
+	var attrs strings.Builder
+	if p.Rotation != 0 {
+		fmt.Fprintf(&attrs, ` transform="rotate(%s %s %s)"`, num(p.Rotation*180/math.Pi), num(p.Center.X), num(p.Center.Y))
+	}
+	if p.Style.Opacity < 1 {
+		fmt.Fprintf(&attrs, ` opacity="%s"`, num(p.Style.Opacity))
+	}
+	return attrs.String()
+}
+
+func strokeAttrs(style Style) string {
+	if style.Stroke == "" {
+		return ` stroke="none"`
+	}
+	attrs := fmt.Sprintf(` stroke="%s" stroke-width="%s" stroke-linecap="round" stroke-linejoin="round"`,
+		escapeAttr(style.Stroke), num(style.StrokeWidth))
+	if len(style.Dash) > 0 {
+		dash := make([]string, len(style.Dash))
+		for i, d := range style.Dash {
+			dash[i] = num(d)
+		}
+		attrs += ` stroke-dasharray="` + strings.Join(dash, " ") + `"`
+	}
+	return attrs
+}
+
+// writeHatchPattern defines the diagonal line pattern used for hachure and
+// cross-hatch fills.
+func writeHatchPattern(defs *bytes.Buffer, id string, style Style) {
+	gap := hatchGap(style.StrokeWidth)
+	width := math.Max(0.5, style.StrokeWidth/2)
+	fmt.Fprintf(defs, `<pattern id="%s" patternUnits="userSpaceOnUse" width="%s" height="%s" patternTransform="rotate(-41)">`,
+		id, num(gap), num(gap))
+	fmt.Fprintf(defs, `<line x1="0" y1="0" x2="0" y2="%s" stroke="%s" stroke-width="%s"/>`,
+		num(gap), escapeAttr(style.Fill), num(width))
+	if style.FillStyle == "cross-hatch" {
+		fmt.Fprintf(defs, `<line x1="0" y1="0" x2="%s" y2="0" stroke="%s" stroke-width="%s"/>`,
+			num(gap), escapeAttr(style.Fill), num(width))
+	}
+	defs.WriteString("</pattern>\n")
+}
+
+func writeText(body *bytes.Buffer, p Primitive, attrs string) {
+	anchor := "start"
+	switch p.Align {
+	case "center":
+		anchor = "middle"
+	case "right":
+		anchor = "end"
+	}
+	for i, line := range p.Lines {
+		y := p.Origin.Y + float64(i)*p.FontSize*p.LineHeight
+		fmt.Fprintf(body, `<text x="%s" y="%s" font-family="%s" font-size="%spx" fill="%s" text-anchor="%s" style="white-space: pre;" direction="ltr" dominant-baseline="alphabetic"%s>`,
+			num(p.Origin.X), num(y), escapeAttr(FontFamily(p.FontFamily)), num(p.FontSize),
+			escapeAttr(p.Style.Fill), anchor, attrs)
+		xml.EscapeText(body, []byte(line))
+		body.WriteString("</text>\n")
+	}
+}
+
+// pathData builds an SVG path through points.
+func pathData(points []Vec, closed bool, radius float64) string {
+	var d strings.Builder
+	for _, segment := range pathSegments(points, closed, radius) {
+		d.WriteByte(segment.op)
+		if segment.op == 'Q' {
+			d.WriteString(num(segment.ctrl.X) + " " + num(segment.ctrl.Y) + " ")
+		}
+		d.WriteString(num(segment.to.X) + " " + num(segment.to.Y) + " ")
+	}
+	if closed {
+		d.WriteString("Z")
+	}
+	return strings.TrimSpace(d.String())
+}
+
+func num(v float64) string {
+	v = math.Round(v*100) / 100
+	if v == 0 {
+		v = 0 // normalise -0
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func escapeAttr(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"draw/pkg/excalidraw"
+)
+
+func TestSVG(t *testing.T) {
+	elements, err := excalidraw.ParseAndValidate([]byte(`[
+		{"id":"frame","type":"frame","x":-20,"y":-20,"width":300,"height":140,"name":"Flow"},
+		{"id":"a","type":"rectangle","x":0,"y":0,"width":100,"height":60,"backgroundColor":"#a5d8ff","frameId":"frame","label":{"text":"Start <here>"}},
+		{"id":"b","type":"ellipse","x":160,"y":0,"width":100,"height":60,"strokeStyle":"dashed","frameId":"frame"},
+		{"id":"c","type":"arrow","x":100,"y":30,"width":60,"height":0,"start":{"id":"a"},"end":{"id":"b"},"frameId":"frame"},
+		{"id":"t","type":"text","x":400,"y":400,"width":80,"height":25,"text":"outside","fontFamily":3},
+		{"id":"gone","type":"rectangle","x":5000,"y":5000,"width":10,"height":10,"isDeleted":true}
+	]`))
+	if err != nil {
+		t.Fatalf("ParseAndValidate() = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    func(*Options)
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name: "whole board",
+			want: []string{
+				`viewBox="-30 -51 520 486"`,
+				`fill="#a5d8ff"`,
+				`Start &lt;here&gt;`,
+				`stroke-dasharray="8 10"`,
+				`font-family="Cascadia, Segoe UI Emoji"`,
+				`>Flow</text>`,
+				`<rect x="-30" y="-51" width="520" height="486" fill="#ffffff"/>`,
+			},
+			notWant: []string{`5000`},
+		},
+		{
+			name: "frame only without background",
+			opts: func(o *Options) {
+				o.FrameID = "frame"
+				o.Background = false
+				o.Padding = 0
+			},
+			want:    []string{`viewBox="-20 -20 300 140"`, `clip-path="url(#frame-clip)"`},
+			notWant: []string{`outside`, `fill="#ffffff"`},
+		},
+		{
+			name: "dark mode",
+			opts: func(o *Options) { o.DarkMode = true },
+			want: []string{`fill="#121212"`},
+		},
+		{
+			name:    "unknown frame",
+			opts:    func(o *Options) { o.FrameID = "nope" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			if tt.opts != nil {
+				tt.opts(&opts)
+			}
+			out, err := SVG(elements, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SVG() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := xml.Unmarshal(out, new(struct{})); err != nil {
+				t.Fatalf("SVG() is not well-formed XML: %v\n%s", err, out)
+			}
+			svg := string(out)
+			for _, want := range tt.want {
+				if !strings.Contains(svg, want) {
+					t.Errorf("SVG() missing %q\n%s", want, svg)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(svg, notWant) {
+					t.Errorf("SVG() unexpectedly contains %q", notWant)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package livekit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// Access is what a participant may do in a board's session.
+type Access int
+
+const (
+	// AccessNone is for users who are not, or no longer, board members.
+	AccessNone Access = iota
+	AccessView
+	AccessEdit
+)
+
+// inRoom reports whether identity is connected to the board's room.
+func (s *LiveKitSession) inRoom(identity string) bool {
+	return s.room != nil && s.room.GetParticipantByIdentity(identity) != nil
+}
+
+// canEdit reports whether the participant is in the room and may currently
+// edit the board. Their token only says what they could do when they joined.
+func (s *LiveKitSession) canEdit(identity string) bool {
+	if !s.inRoom(identity) {
+		return false
+	}
+	access, err := s.participantAccess(identity)
+	if err != nil {
+		logger.Errorw("Failed to get participant access", err, "boardID", s.boardID, "participant", identity)
+		return false
+	}
+	return access == AccessEdit
+}
+
+// participantAccess reads identity's access through GetAccess the first time
+// it is needed and caches it until it is forgotten or refreshed.
+func (s *LiveKitSession) participantAccess(identity string) (Access, error) {
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+	if access, ok := s.access[identity]; ok {
+		return access, nil
+	}
+	if s.callbacks.GetAccess == nil {
+		return AccessNone, nil
+	}
+	access, err := s.callbacks.GetAccess(s.boardID, identity)
+	if err != nil {
+		return AccessNone, err
+	}
+	s.access[identity] = access
+	return access, nil
+}
+
+func (s *LiveKitSession) forgetAccess(identity string) {
+	s.accessMu.Lock()
+	delete(s.access, identity)
+	s.accessMu.Unlock()
+}
+
+// RefreshAccess re-reads identity's access after their membership changed
+// and brings their connection in line with it: a participant who is no
+// longer a member is removed from the room, and one who may no longer edit
+// stops publishing media.
+func (s *LiveKitSession) RefreshAccess(ctx context.Context, identity string) error {
+	s.forgetAccess(identity)
+	if !s.inRoom(identity) {
+		return nil
+	}
+	access, err := s.participantAccess(identity)
+	if err != nil {
+		return fmt.Errorf("failed to get participant access: %w", err)
+	}
+
+	roomClient := lksdk.NewRoomServiceClient(
+		s.lkConfig.Host,
+		s.lkConfig.APIKey,
+		s.lkConfig.APISecret,
+	)
+	if access == AccessNone {
+		if _, err := roomClient.RemoveParticipant(ctx, &livekit.RoomParticipantIdentity{
+			Room:     s.boardID,
+			Identity: identity,
+		}); err != nil {
+			return fmt.Errorf("failed to remove participant: %w", err)
+		}
+		return nil
+	}
+	if _, err := roomClient.UpdateParticipant(ctx, &livekit.UpdateParticipantRequest{
+		Room:       s.boardID,
+		Identity:   identity,
+		Permission: participantPermission(access),
+	}); err != nil {
+		return fmt.Errorf("failed to update participant permissions: %w", err)
+	}
+	return nil
+}
+
+// participantPermission is what a member with access may do in the room,
+// matching the token GenerateUserToken gives them. Every member may publish
+// data so that viewers can chat; handleBoardStream keeps their board changes
+// out.
+func participantPermission(access Access) *livekit.ParticipantPermission {
+	return &livekit.ParticipantPermission{
+		CanSubscribe:   true,
+		CanPublish:     access == AccessEdit,
+		CanPublishData: true,
+	}
+}
@@ -0,0 +1,178 @@
+package livekit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"draw/pkg/excalidraw"
+	"draw/pkg/llm"
+
+	"github.com/livekit/protocol/logger"
+)
+
+const (
+	// assistantName is what the bot is called in transcripts.
+	assistantName = "Assistant"
+	// maxDescribedElements caps how much of the board goes into a prompt.
+	maxDescribedElements = 200
+)
+
+// assistantRequest is something a participant asked the bot, said aloud or
+// typed.
+type assistantRequest struct {
+	identity string
+	name     string
+	text     string
+	// topic is where the request came from and where the reply goes.
+	topic string
+}
+
+// handleRequest asks the LLM to answer a participant, applies the board
+// operations in its answer and sends its reply on the request's topic.
+func (s *LiveKitSession) handleRequest(req assistantRequest) {
+	prompt := llm.WithBoard(s.describeBoard(), llm.Attribute(req.name, req.text))
+	response, err := s.llmClient.GenerateResponse(s.ctx, prompt)
+	if err != nil {
+		if s.stopped() {
+			return
+		}
+		logger.Errorw("LLM error", err, "boardID", s.boardID, "speaker", req.identity)
+		if s.callbacks.OnLLMResponse != nil {
+			s.callbacks.OnLLMResponse(s.boardID, nil, err)
+		}
+		return
+	}
+	response.Speaker = req.identity
+
+	if len(response.Operations) > 0 {
+		if err := s.applyBoardOps(req.identity, response.Operations); err != nil {
+			logger.Warnw("Dropped board operations", err, "boardID", s.boardID, "speaker", req.identity)
+		}
+	}
+	if response.Response != "" {
+		s.reply(req, response)
+	}
+	if s.callbacks.OnLLMResponse != nil {
+		s.callbacks.OnLLMResponse(s.boardID, response, nil)
+	}
+}
+
+// reply sends the LLM's answer to req where it came from, naming who it is
+// for. Answers to spoken requests are also spoken.
+func (s *LiveKitSession) reply(req assistantRequest, response *llm.LLMResponse) {
+	timestamp := response.Timestamp.UTC().Format(time.RFC3339Nano)
+	if req.topic == TopicChat {
+		s.sendText(TopicChat, StreamTextData{
+			Type: StreamTypeMessage,
+			Data: ChatMessage{
+				Role:      "ai",
+				Identity:  botIdentity,
+				Name:      assistantName,
+				Content:   response.Response,
+				Timestamp: timestamp,
+				ReplyTo:   req.identity,
+			},
+		})
+		return
+	}
+	s.sendText(TopicRoom, StreamTextData{
+		Type: StreamTypeTranscript,
+		Data: TranscriptData{
+			Role:      "ai",
+			Identity:  botIdentity,
+			Name:      assistantName,
+			Content:   response.Response,
+			Timestamp: timestamp,
+			ReplyTo:   req.identity,
+		},
+	})
+	s.speak(response.Response)
+}
+
+// applyBoardOps carries out operations the LLM asked for on identity's
+// behalf and broadcasts the elements they changed. Operations that make no
+// sense against the board are skipped; if the rest would leave it invalid,
+// none are applied.
+func (s *LiveKitSession) applyBoardOps(identity string, ops []llm.BoardOp) error {
+	if !s.canEdit(identity) {
+		return fmt.Errorf("participant %s may not edit the board", identity)
+	}
+
+	s.board.mu.Lock()
+	changed, skipped := planBoardOps(s.board.elements, ops, time.Now().UnixMilli())
+	accepted, err := s.commitElements(identity, changed)
+	s.board.mu.Unlock()
+	for _, reason := range skipped {
+		logger.Infow("Skipped board operation", "boardID", s.boardID, "reason", reason.Error())
+	}
+	if err != nil {
+		return err
+	}
+	// Sent as the bot's own change so that the speaker's client applies it
+	// too rather than taking it for an echo.
+	s.broadcastElements(botIdentity, accepted)
+	return nil
+}
+
+// describeBoard lists the live elements for a prompt, one per line, with the
+// ids the LLM should use to refer to them.
+func (s *LiveKitSession) describeBoard() string {
+	s.board.mu.Lock()
+	defer s.board.mu.Unlock()
+
+	boundText := make(map[string]string)
+	for _, element := range s.board.elements {
+		if !element.IsDeleted && element.Type == excalidraw.TypeText && element.ContainerID != nil {
+			boundText[*element.ContainerID] = element.Text
+		}
+	}
+
+	var b strings.Builder
+	described := 0
+	for _, element := range s.board.elements {
+		if element.IsDeleted || (element.Type == excalidraw.TypeText && element.ContainerID != nil) {
+			continue
+		}
+		if described == maxDescribedElements {
+			b.WriteString("- (more elements not listed)\n")
+			break
+		}
+		described++
+
+		fmt.Fprintf(&b, "- id=%s %s at (%.0f, %.0f) size %.0fx%.0f",
+			element.ID, element.Type, element.X, element.Y, element.Width, element.Height)
+		if element.Type.IsLinear() {
+			if from := linkedID(element.Start, element.StartBinding); from != "" {
+				fmt.Fprintf(&b, " from %s", from)
+			}
+			if to := linkedID(element.End, element.EndBinding); to != "" {
+				fmt.Fprintf(&b, " to %s", to)
+			}
+		}
+		text := element.Text
+		if element.Label != nil {
+			text = element.Label.Text
+		}
+		if bound, ok := boundText[element.ID]; ok {
+			text = bound
+		}
+		if text != "" {
+			fmt.Fprintf(&b, " text %q", text)
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// linkedID is the element one end of an arrow is attached to, in either the
+// skeleton or the scene form.
+func linkedID(ref *excalidraw.ElementRef, binding *excalidraw.Binding) string {
+	if ref != nil && ref.ID != "" {
+		return ref.ID
+	}
+	if binding != nil {
+		return binding.ElementID
+	}
+	return ""
+}
@@ -0,0 +1,343 @@
+package livekit
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"draw/pkg/excalidraw"
+	"draw/pkg/llm"
+)
+
+// Sizes of what the assistant draws, in scene units, when it does not say.
+const (
+	opShapeWidth  = 160
+	opShapeHeight = 80
+	opFontSize    = 20
+	// opMaxSize bounds the width and height the assistant may ask for.
+	opMaxSize = 10000
+)
+
+var colorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// opPlanner turns assistant operations into element changes. Operations are
+// checked one at a time against the board as the earlier ones left it; one
+// that does not make sense is skipped and the rest still apply.
+type opPlanner struct {
+	now int64
+	// elements are the live elements by id, with changes applied.
+	elements map[string]excalidraw.Element
+	// aliases maps the ids the model gave elements it added to their real ids.
+	aliases map[string]string
+	changed []string
+	touched map[string]bool
+}
+
+// planBoardOps returns the elements ops change, ready to be reconciled into
+// elements, and why each skipped operation was skipped. Changed elements get
+// a new version so that clients take them over their own copies. Deletes are
+// elements with isDeleted set.
+func planBoardOps(elements []excalidraw.Element, ops []llm.BoardOp, now int64) ([]excalidraw.Element, []error) {
+	p := &opPlanner{
+		now:      now,
+		elements: make(map[string]excalidraw.Element, len(elements)),
+		aliases:  make(map[string]string),
+		touched:  make(map[string]bool),
+	}
+	for _, element := range elements {
+		if !element.IsDeleted {
+			p.elements[element.ID] = element
+		}
+	}
+
+	var skipped []error
+	for i, op := range ops {
+		if err := p.apply(op); err != nil {
+			skipped = append(skipped, fmt.Errorf("operation %d (%s): %w", i, op.Type, err))
+		}
+	}
+
+	changed := make([]excalidraw.Element, 0, len(p.changed))
+	for _, id := range p.changed {
+		changed = append(changed, p.elements[id])
+	}
+	return changed, skipped
+}
+
+func (p *opPlanner) apply(op llm.BoardOp) error {
+	switch op.Type {
+	case llm.BoardOpAddShape:
+		shape := excalidraw.ElementType(op.Shape)
+		if op.Shape == "" {
+			shape = excalidraw.TypeRectangle
+		}
+		if !shape.IsShape() {
+			return fmt.Errorf("unknown shape %q", op.Shape)
+		}
+		if err := checkColors(op); err != nil {
+			return err
+		}
+		width, height, err := opSize(op)
+		if err != nil {
+			return err
+		}
+		element := excalidraw.Element{
+			Type:            shape,
+			X:               op.X,
+			Y:               op.Y,
+			Width:           width,
+			Height:          height,
+			StrokeColor:     op.StrokeColor,
+			BackgroundColor: op.BackgroundColor,
+		}
+		if op.BackgroundColor != "" {
+			element.FillStyle = "solid"
+		}
+		if op.Text != "" {
+			element.Label = &excalidraw.Label{Text: op.Text}
+		}
+		return p.add(op.ID, element)
+
+	case llm.BoardOpAddText:
+		if strings.TrimSpace(op.Text) == "" {
+			return fmt.Errorf("text is empty")
+		}
+		if err := checkColors(op); err != nil {
+			return err
+		}
+		width, height := textSize(op.Text)
+		return p.add(op.ID, excalidraw.Element{
+			Type:        excalidraw.TypeText,
+			X:           op.X,
+			Y:           op.Y,
+			Width:       width,
+			Height:      height,
+			StrokeColor: op.StrokeColor,
+			Text:        op.Text,
+			FontSize:    opFontSize,
+		})
+
+	case llm.BoardOpConnect:
+		from, err := p.resolve(op.From)
+		if err != nil {
+			return err
+		}
+		to, err := p.resolve(op.To)
+		if err != nil {
+			return err
+		}
+		if from.ID == to.ID {
+			return fmt.Errorf("cannot connect %q to itself", op.From)
+		}
+		if from.Type.IsLinear() || to.Type.IsLinear() {
+			return fmt.Errorf("arrows can only connect shapes and text")
+		}
+		fx, fy := center(from)
+		tx, ty := center(to)
+		x, y := border(from, tx, ty)
+		ex, ey := border(to, fx, fy)
+		arrow := excalidraw.Element{
+			Type:   excalidraw.TypeArrow,
+			X:      x,
+			Y:      y,
+			Width:  math.Abs(ex - x),
+			Height: math.Abs(ey - y),
+			Points: []excalidraw.Point{{0, 0}, {ex - x, ey - y}},
+			Start:  &excalidraw.ElementRef{ID: from.ID},
+			End:    &excalidraw.ElementRef{ID: to.ID},
+		}
+		if op.Text != "" {
+			arrow.Label = &excalidraw.Label{Text: op.Text}
+		}
+		return p.add("", arrow)
+
+	case llm.BoardOpMove:
+		element, err := p.resolve(op.ID)
+		if err != nil {
+			return err
+		}
+		dx, dy := op.X-element.X, op.Y-element.Y
+		element.X, element.Y = op.X, op.Y
+		p.update(element)
+		// Text drawn inside the element moves with it.
+		for _, text := range p.boundText(element.ID) {
+			text.X += dx
+			text.Y += dy
+			p.update(text)
+		}
+		return nil
+
+	case llm.BoardOpRestyle:
+		element, err := p.resolve(op.ID)
+		if err != nil {
+			return err
+		}
+		if op.StrokeColor == "" && op.BackgroundColor == "" {
+			return fmt.Errorf("no color given")
+		}
+		if err := checkColors(op); err != nil {
+			return err
+		}
+		if op.StrokeColor != "" {
+			element.StrokeColor = op.StrokeColor
+		}
+		if op.BackgroundColor != "" {
+			element.BackgroundColor = op.BackgroundColor
+			if element.FillStyle == "" {
+				element.FillStyle = "solid"
+			}
+		}
+		p.update(element)
+		return nil
+
+	case llm.BoardOpDelete:
+		element, err := p.resolve(op.ID)
+		if err != nil {
+			return err
+		}
+		p.delete(element.ID)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown operation")
+	}
+}
+
+// resolve finds a live element by its board id or by the id the model gave
+// it when adding it.
+func (p *opPlanner) resolve(id string) (excalidraw.Element, error) {
+	if id == "" {
+		return excalidraw.Element{}, fmt.Errorf("no element id given")
+	}
+	if real, ok := p.aliases[id]; ok {
+		id = real
+	}
+	element, ok := p.elements[id]
+	if !ok || element.IsDeleted {
+		return excalidraw.Element{}, fmt.Errorf("no element %q", id)
+	}
+	return element, nil
+}
+
+func (p *opPlanner) add(alias string, element excalidraw.Element) error {
+	if alias != "" {
+		if _, err := p.resolve(alias); err == nil {
+			return fmt.Errorf("id %q is already in use", alias)
+		}
+	}
+	element.ID = excalidraw.NewID()
+	element.Version = 1
+	element.VersionNonce = rand.Int64N(math.MaxInt32)
+	element.Updated = p.now
+	if alias != "" {
+		p.aliases[alias] = element.ID
+	}
+	p.elements[element.ID] = element
+	p.changed = append(p.changed, element.ID)
+	p.touched[element.ID] = true
+	return nil
+}
+
+// update stores a changed element, bumping its version the first time it is
+// changed by this plan.
+func (p *opPlanner) update(element excalidraw.Element) {
+	if !p.touched[element.ID] {
+		element.Version++
+		element.VersionNonce = rand.Int64N(math.MaxInt32)
+		element.Updated = p.now
+		p.changed = append(p.changed, element.ID)
+		p.touched[element.ID] = true
+	}
+	p.elements[element.ID] = element
+}
+
+// delete removes id as excalidraw.DeleteElement does for API edits, taking
+// its bound text with it and dropping the bindings other elements hold to it.
+func (p *opPlanner) delete(id string) {
+	elements := make([]excalidraw.Element, 0, len(p.elements))
+	for _, element := range p.elements {
+		elements = append(elements, element)
+	}
+	excalidraw.DeleteElement(elements, id, p.now)
+	for _, element := range elements {
+		before := p.elements[element.ID]
+		if element.Version == before.Version {
+			continue
+		}
+		// update gives the element its version for this plan.
+		element.Version = before.Version
+		element.VersionNonce = before.VersionNonce
+		element.Updated = before.Updated
+		p.update(element)
+	}
+}
+
+// boundText returns the live text elements contained in id.
+func (p *opPlanner) boundText(id string) []excalidraw.Element {
+	var texts []excalidraw.Element
+	for _, element := range p.elements {
+		if !element.IsDeleted && element.ContainerID != nil && *element.ContainerID == id {
+			texts = append(texts, element)
+		}
+	}
+	return texts
+}
+
+func checkColors(op llm.BoardOp) error {
+	for _, color := range []string{op.StrokeColor, op.BackgroundColor} {
+		if color != "" && color != "transparent" && !colorPattern.MatchString(color) {
+			return fmt.Errorf("invalid color %q", color)
+		}
+	}
+	return nil
+}
+
+func opSize(op llm.BoardOp) (float64, float64, error) {
+	width, height := op.Width, op.Height
+	if width == 0 {
+		width = opShapeWidth
+	}
+	if height == 0 {
+		height = opShapeHeight
+	}
+	if width < 0 || height < 0 || width > opMaxSize || height > opMaxSize {
+		return 0, 0, fmt.Errorf("size %gx%g is out of range", width, height)
+	}
+	return width, height, nil
+}
+
+// textSize estimates the box of text at opFontSize; the frontend measures it
+// properly when it loads the element.
+func textSize(text string) (float64, float64) {
+	lines := strings.Split(text, "\n")
+	longest := 0
+	for _, line := range lines {
+		longest = max(longest, utf8.RuneCountInString(line))
+	}
+	return float64(longest) * opFontSize * 0.6, float64(len(lines)) * opFontSize * 1.25
+}
+
+func center(element excalidraw.Element) (float64, float64) {
+	return element.X + element.Width/2, element.Y + element.Height/2
+}
+
+// border returns where the segment from the element's center towards
+// (tx, ty) leaves its box.
+func border(element excalidraw.Element, tx, ty float64) (float64, float64) {
+	cx, cy := center(element)
+	dx, dy := tx-cx, ty-cy
+	if dx == 0 && dy == 0 {
+		return cx, cy
+	}
+	t := math.Inf(1)
+	if dx != 0 {
+		t = math.Min(t, element.Width/2/math.Abs(dx))
+	}
+	if dy != 0 {
+		t = math.Min(t, element.Height/2/math.Abs(dy))
+	}
+	return cx + dx*t, cy + dy*t
+}
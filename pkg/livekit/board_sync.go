@@ -0,0 +1,289 @@
+package livekit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"draw/pkg/excalidraw"
+
+	"github.com/livekit/protocol/logger"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// TopicBoard carries board traffic in both directions: participants send
+// their local element changes to the bot, and the bot broadcasts what it
+// merged. Every member may publish data, so a participant can also send board
+// messages to their peers directly; clients apply board changes only from the
+// bot, whose broadcasts carry what it checked.
+const TopicBoard = "board"
+
+// StreamTypeElements tags an ElementsDelta on the board topic.
+const StreamTypeElements = "elements"
+
+// ElementsDelta is a batch of changed elements. Excalidraw deletes by setting
+// isDeleted, so a delta is always a list of whole elements.
+type ElementsDelta struct {
+	Elements []excalidraw.Element `json:"elements"`
+	// From is the identity of the participant the change came from.
+	From string `json:"from,omitempty"`
+}
+
+// boardState is the bot's authoritative copy of the board. Participant deltas
+// are merged into it by element version, and the elements they changed are
+// written back to storage once edits have been quiet for the save delay.
+type boardState struct {
+	mu       sync.Mutex
+	elements []excalidraw.Element
+	// pending holds the ids of elements changed since the last save.
+	pending map[string]bool
+	// author is the identity of the participant who made the latest change
+	// waiting to be saved. The save is credited to them.
+	author    string
+	saveTimer *time.Timer
+	// saveMu keeps saves from overlapping, so that each one sees what the
+	// one before it stored.
+	saveMu sync.Mutex
+}
+
+// loadBoard seeds the board state from storage.
+func (s *LiveKitSession) loadBoard() error {
+	if s.callbacks.GetBoardState == nil {
+		return nil
+	}
+	raw, err := s.callbacks.GetBoardState(s.boardID)
+	if err != nil {
+		return err
+	}
+	elements, err := excalidraw.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse board elements: %w", err)
+	}
+
+	s.board.mu.Lock()
+	s.board.elements = elements
+	s.board.mu.Unlock()
+	return nil
+}
+
+// handleBoardStream merges a participant's element changes. Every member may
+// publish data so that viewers can chat, so the board is guarded here: deltas
+// from participants who may not edit are dropped unread.
+func (s *LiveKitSession) handleBoardStream(reader *lksdk.TextStreamReader, identity string) {
+	if !s.canEdit(identity) {
+		logger.Warnw("Dropping delta from read-only participant", nil, "participant", identity)
+		return
+	}
+	var message struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(reader.ReadAll()), &message); err != nil {
+		logger.Warnw("Malformed board message", err, "participant", identity)
+		return
+	}
+	if message.Type != StreamTypeElements {
+		return
+	}
+	var delta ElementsDelta
+	if err := json.Unmarshal(message.Data, &delta); err != nil {
+		logger.Warnw("Malformed elements delta", err, "participant", identity)
+		return
+	}
+	s.mergeElements(identity, delta.Elements)
+}
+
+// mergeElements applies a participant's delta and broadcasts the elements
+// that won. A delta that would leave the board invalid is dropped whole.
+func (s *LiveKitSession) mergeElements(identity string, incoming []excalidraw.Element) {
+	s.board.mu.Lock()
+	accepted, err := s.commitElements(identity, incoming)
+	s.board.mu.Unlock()
+	if err != nil {
+		logger.Warnw("Rejected elements delta", err, "participant", identity)
+		return
+	}
+	s.broadcastElements(identity, accepted)
+}
+
+// commitElements reconciles incoming, changed by author, into the board and
+// schedules a save. It returns the elements that won, or an error, leaving
+// the board as it was, if the result would be invalid. Callers hold
+// s.board.mu.
+func (s *LiveKitSession) commitElements(author string, incoming []excalidraw.Element) ([]excalidraw.Element, error) {
+	merged, accepted := excalidraw.Reconcile(s.board.elements, incoming)
+	if len(accepted) == 0 {
+		return nil, nil
+	}
+	if err := excalidraw.Validate(merged); err != nil {
+		return nil, err
+	}
+	if err := excalidraw.CheckLimits(merged, s.limits); err != nil {
+		return nil, err
+	}
+	s.board.elements = merged
+	for _, element := range accepted {
+		s.board.pending[element.ID] = true
+	}
+	s.board.author = author
+	s.scheduleSave()
+	return accepted, nil
+}
+
+// scheduleSave starts the save timer if it is not running. Callers hold
+// s.board.mu.
+func (s *LiveKitSession) scheduleSave() {
+	if s.board.saveTimer == nil {
+		s.board.saveTimer = time.AfterFunc(s.boardConfig.SyncSaveDelay, s.saveBoard)
+	}
+}
+
+// broadcastElements tells the room about elements changed by from.
+func (s *LiveKitSession) broadcastElements(from string, elements []excalidraw.Element) {
+	if len(elements) == 0 {
+		return
+	}
+	s.sendText(TopicBoard, StreamTextData{
+		Type: StreamTypeElements,
+		Data: ElementsDelta{Elements: elements, From: from},
+	})
+}
+
+// saveBoard writes the elements changed since the last save back to storage
+// in one save, credited to the participant who changed the board last. The
+// changes are saved together because they may depend on each other, such as
+// a shape bound to an arrow someone else drew. Changes saved through the API
+// meanwhile are taken into the board first, so that the live copy neither
+// overwrites them nor brings back what they deleted.
+func (s *LiveKitSession) saveBoard() {
+	s.board.saveMu.Lock()
+	defer s.board.saveMu.Unlock()
+
+	s.board.mu.Lock()
+	s.board.saveTimer = nil
+	idle := len(s.board.pending) == 0 || s.callbacks.SaveBoardState == nil
+	s.board.mu.Unlock()
+	if idle {
+		return
+	}
+
+	stored, reloaded := s.reloadBoard()
+
+	s.board.mu.Lock()
+	var fromStorage []excalidraw.Element
+	if reloaded {
+		fromStorage = s.takeStored(stored)
+	}
+	pending := s.board.pending
+	author := s.board.author
+	s.board.pending = make(map[string]bool)
+	s.board.author = ""
+	changed := make([]excalidraw.Element, 0, len(pending))
+	for _, element := range s.board.elements {
+		if pending[element.ID] {
+			changed = append(changed, element)
+		}
+	}
+	s.board.mu.Unlock()
+	s.broadcastElements(botIdentity, fromStorage)
+
+	raw, err := excalidraw.Marshal(changed)
+	if err == nil {
+		err = s.callbacks.SaveBoardState(s.boardID, author, raw)
+	}
+	if err != nil {
+		logger.Errorw("Failed to save board", err, "boardID", s.boardID, "elements", pendingIDs(pending))
+		// Retried after the save delay rather than straight away, which
+		// would fail the same way while storage is down.
+		s.board.mu.Lock()
+		for id := range pending {
+			s.board.pending[id] = true
+		}
+		if s.board.author == "" {
+			s.board.author = author
+		}
+		s.scheduleSave()
+		s.board.mu.Unlock()
+	}
+}
+
+func pendingIDs(pending map[string]bool) []string {
+	ids := make([]string, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// reloadBoard reads the board as storage holds it. Failing that, saving is
+// still safe, since storage reconciles what it is sent.
+func (s *LiveKitSession) reloadBoard() ([]excalidraw.Element, bool) {
+	if s.callbacks.GetBoardState == nil {
+		return nil, false
+	}
+	raw, err := s.callbacks.GetBoardState(s.boardID)
+	if err != nil {
+		logger.Warnw("Failed to reload board before saving", err, "boardID", s.boardID)
+		return nil, false
+	}
+	elements, err := excalidraw.Parse(raw)
+	if err != nil {
+		logger.Warnw("Failed to parse stored board", err, "boardID", s.boardID)
+		return nil, false
+	}
+	return elements, true
+}
+
+// takeStored merges the board as storage holds it into the live copy and
+// returns the elements that changed. Stored elements that supersede live
+// ones win. Live elements missing from storage were deleted through the API,
+// unless they are waiting to be saved; they become deleted elements with a
+// new version, saved with the rest, so that no client's copy can bring them
+// back. Callers hold s.board.mu.
+func (s *LiveKitSession) takeStored(stored []excalidraw.Element) []excalidraw.Element {
+	merged, accepted := excalidraw.Reconcile(s.board.elements, stored)
+
+	inStorage := make(map[string]bool, len(stored))
+	for _, element := range stored {
+		inStorage[element.ID] = true
+	}
+	now := time.Now().UnixMilli()
+	for i := range merged {
+		element := &merged[i]
+		if inStorage[element.ID] || s.board.pending[element.ID] || element.IsDeleted {
+			continue
+		}
+		element.IsDeleted = true
+		element.Version++
+		element.VersionNonce = rand.Int64N(math.MaxInt32)
+		element.Updated = now
+		s.board.pending[element.ID] = true
+		accepted = append(accepted, *element)
+	}
+	s.board.elements = merged
+	return accepted
+}
+
+// flushBoard cancels a scheduled save and runs it now, as the session's last
+// save. Whatever it fails to store is lost, and logged as such.
+func (s *LiveKitSession) flushBoard() {
+	s.board.mu.Lock()
+	if s.board.saveTimer != nil {
+		s.board.saveTimer.Stop()
+	}
+	s.board.mu.Unlock()
+	s.saveBoard()
+
+	s.board.mu.Lock()
+	defer s.board.mu.Unlock()
+	if s.board.saveTimer != nil {
+		s.board.saveTimer.Stop()
+		s.board.saveTimer = nil
+	}
+	if len(s.board.pending) > 0 {
+		logger.Errorw("Dropping unsaved board changes", nil, "boardID", s.boardID, "elements", pendingIDs(s.board.pending))
+	}
+}
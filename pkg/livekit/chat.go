@@ -0,0 +1,74 @@
+package livekit
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/livekit/protocol/logger"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// TopicChat carries typed conversation with the bot. Participants send
+// messages to the room and the bot answers on the same topic.
+const TopicChat = "chat"
+
+// StreamTypeMessage tags a ChatMessage on the chat topic.
+const StreamTypeMessage = "message"
+
+// maxChatMessageLength caps, in characters, what is passed on to the LLM.
+const maxChatMessageLength = 2000
+
+// ChatMessage is one message on the chat topic. Participants only need to
+// fill in Content; the sender is known from the stream.
+type ChatMessage struct {
+	// Role is "user" for participants and "ai" for the bot.
+	Role      string `json:"role,omitempty"`
+	Identity  string `json:"identity,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Content   string `json:"content"`
+	Timestamp string `json:"timestamp,omitempty"`
+	// ReplyTo is the identity of the participant the bot is answering.
+	ReplyTo string `json:"replyTo,omitempty"`
+}
+
+// handleChatStream passes a participant's typed message to the assistant.
+// Other participants receive the message from the sender directly.
+func (s *LiveKitSession) handleChatStream(reader *lksdk.TextStreamReader, identity string) {
+	var message struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(reader.ReadAll()), &message); err != nil {
+		logger.Warnw("Malformed chat message", err, "participant", identity)
+		return
+	}
+	if message.Type != StreamTypeMessage {
+		return
+	}
+	var chat ChatMessage
+	if err := json.Unmarshal(message.Data, &chat); err != nil {
+		logger.Warnw("Malformed chat message", err, "participant", identity)
+		return
+	}
+	text := strings.TrimSpace(chat.Content)
+	if text == "" {
+		return
+	}
+	if utf8.RuneCountInString(text) > maxChatMessageLength {
+		logger.Warnw("Dropping oversized chat message", nil, "participant", identity)
+		return
+	}
+
+	// The sender's name comes from their token, not from the message.
+	name := identity
+	if participant := s.room.GetParticipantByIdentity(identity); participant != nil && participant.Name() != "" {
+		name = participant.Name()
+	}
+	s.handleRequest(assistantRequest{
+		identity: identity,
+		name:     name,
+		text:     text,
+		topic:    TopicChat,
+	})
+}
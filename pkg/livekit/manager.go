@@ -0,0 +1,273 @@
+package livekit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/livekit/protocol/logger"
+)
+
+// ErrManagerClosed is returned by Join once Shutdown has begun.
+var ErrManagerClosed = errors.New("session manager is shut down")
+
+// Manager owns the live session of each board. The first join starts a
+// session, later joins share it, and it is stopped once its room has had no
+// participants for the idle timeout.
+type Manager struct {
+	idleTimeout time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*managedSession
+	closed   bool
+}
+
+type managedSession struct {
+	session *LiveKitSession
+	// ready is closed once the session has started, or failed to with err.
+	ready chan struct{}
+	err   error
+	// participants holds the identities connected to the room. LiveKit
+	// allows one connection per identity, so this counts users.
+	participants map[string]bool
+	idleTimer    *time.Timer
+	// idleGen invalidates idle timers that fired while being replaced.
+	idleGen int
+}
+
+func NewManager(idleTimeout time.Duration) *Manager {
+	return &Manager{
+		idleTimeout: idleTimeout,
+		sessions:    make(map[string]*managedSession),
+	}
+}
+
+// Join returns the board's running session, starting one made by create if
+// there is none. The session stays up for at least the idle timeout so the
+// caller has time to connect.
+func (m *Manager) Join(boardID string, create func() (*LiveKitSession, error)) (*LiveKitSession, error) {
+	for {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return nil, ErrManagerClosed
+		}
+		ms, ok := m.sessions[boardID]
+		if !ok {
+			ms = &managedSession{
+				ready:        make(chan struct{}),
+				participants: make(map[string]bool),
+			}
+			m.sessions[boardID] = ms
+			m.mu.Unlock()
+			m.start(boardID, ms, create)
+		} else {
+			m.mu.Unlock()
+		}
+
+		<-ms.ready
+		if ms.err != nil {
+			return nil, ms.err
+		}
+
+		m.mu.Lock()
+		if m.sessions[boardID] != ms || ms.session.stopped() {
+			// Stopped between lookup and now; start over with a new one.
+			m.remove(boardID, ms)
+			m.mu.Unlock()
+			continue
+		}
+		if len(ms.participants) == 0 {
+			m.armIdle(boardID, ms)
+		}
+		m.mu.Unlock()
+		return ms.session, nil
+	}
+}
+
+// Leave stops counting identity as present in the board's room. It is for
+// clients that say they are leaving; disconnects are noticed on their own.
+// The room has the last word: an identity still connected, say from another
+// tab, stays present.
+func (m *Manager) Leave(boardID string, identity string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ms, ok := m.sessions[boardID]
+	if !ok || ms.session == nil || ms.session.inRoom(identity) {
+		return
+	}
+	delete(ms.participants, identity)
+	if len(ms.participants) == 0 {
+		m.armIdle(boardID, ms)
+	}
+}
+
+// End stops the board's session, if it has one, once it has saved its pending
+// edits. Participants are disconnected; joining again starts a new session.
+// Failures are only logged: the session is stopped either way.
+func (m *Manager) End(boardID string) {
+	m.mu.Lock()
+	ms, ok := m.sessions[boardID]
+	if ok {
+		m.remove(boardID, ms)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+	<-ms.ready
+	if ms.session == nil {
+		return
+	}
+	if err := ms.session.Stop(); err != nil {
+		logger.Errorw("Failed to stop session", err, "boardID", boardID)
+	}
+}
+
+// Session returns the board's running session, if it has one.
+func (m *Manager) Session(boardID string) (*LiveKitSession, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ms, ok := m.sessions[boardID]
+	if !ok || ms.session == nil || ms.session.stopped() {
+		return nil, false
+	}
+	return ms.session, true
+}
+
+// RefreshAccess tells the board's running session, if it has one, that
+// identity's membership changed. Failures are only logged: the change is
+// already saved, and the session rechecks access on every edit.
+func (m *Manager) RefreshAccess(ctx context.Context, boardID string, identity string) {
+	session, ok := m.Session(boardID)
+	if !ok {
+		return
+	}
+	if err := session.RefreshAccess(ctx, identity); err != nil {
+		logger.Errorw("Failed to refresh participant access", err, "boardID", boardID, "participant", identity)
+	}
+}
+
+// Shutdown stops every session and refuses new joins. It returns early with
+// ctx's error if the sessions do not stop in time.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	pending := make([]*managedSession, 0, len(m.sessions))
+	for boardID, ms := range m.sessions {
+		pending = append(pending, ms)
+		if ms.idleTimer != nil {
+			ms.idleTimer.Stop()
+		}
+		delete(m.sessions, boardID)
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(pending))
+	for i, ms := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ms.ready
+			if ms.session != nil {
+				errs[i] = ms.session.Stop()
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return errors.Join(errs...)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *Manager) start(boardID string, ms *managedSession, create func() (*LiveKitSession, error)) {
+	session, err := create()
+	if err == nil {
+		session.presence = func(identity string, connected bool) {
+			m.presence(boardID, ms, identity, connected)
+		}
+		if err = session.Start(); err != nil {
+			session.Stop()
+			err = fmt.Errorf("failed to start session: %w", err)
+		}
+	}
+
+	m.mu.Lock()
+	if err != nil {
+		ms.err = err
+		m.remove(boardID, ms)
+	} else {
+		ms.session = session
+		go m.watch(boardID, ms)
+	}
+	m.mu.Unlock()
+	close(ms.ready)
+}
+
+// watch forgets a session once it has stopped, however that happened.
+func (m *Manager) watch(boardID string, ms *managedSession) {
+	<-ms.session.Done()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(boardID, ms)
+}
+
+func (m *Manager) presence(boardID string, ms *managedSession, identity string, connected bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if connected {
+		ms.participants[identity] = true
+		m.disarmIdle(ms)
+		return
+	}
+	delete(ms.participants, identity)
+	if len(ms.participants) == 0 && m.sessions[boardID] == ms {
+		m.armIdle(boardID, ms)
+	}
+}
+
+// armIdle (re)starts the countdown to stopping an empty session. Callers hold
+// m.mu.
+func (m *Manager) armIdle(boardID string, ms *managedSession) {
+	m.disarmIdle(ms)
+	gen := ms.idleGen
+	ms.idleTimer = time.AfterFunc(m.idleTimeout, func() {
+		m.mu.Lock()
+		if ms.idleGen != gen || ms.session == nil || len(ms.participants) > 0 || m.sessions[boardID] != ms {
+			m.mu.Unlock()
+			return
+		}
+		m.remove(boardID, ms)
+		m.mu.Unlock()
+		ms.session.Stop()
+	})
+}
+
+// disarmIdle cancels a pending idle stop. Callers hold m.mu.
+func (m *Manager) disarmIdle(ms *managedSession) {
+	ms.idleGen++
+	if ms.idleTimer != nil {
+		ms.idleTimer.Stop()
+		ms.idleTimer = nil
+	}
+}
+
+// remove drops ms from the registry if it is still the board's entry.
+// Callers hold m.mu.
+func (m *Manager) remove(boardID string, ms *managedSession) {
+	if m.sessions[boardID] == ms {
+		delete(m.sessions, boardID)
+	}
+	m.disarmIdle(ms)
+}
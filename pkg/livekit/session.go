@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"draw/pkg/config"
+	"draw/pkg/excalidraw"
 	"draw/pkg/llm"
 	"draw/pkg/speech"
 
@@ -27,18 +29,32 @@ type SessionCallbacks struct {
 	OnMeetingEnd  func(meetingID string, recordingURL string, transcriptURL string, err error)
 	OnLLMResponse func(boardID string, response *llm.LLMResponse, err error)
 	GetBoardState func(boardID string) (json.RawMessage, error)
+	// SaveBoardState persists elements merged from live edits. They are
+	// credited to the participant with the given identity, the last to
+	// change the board, or to no one if it is empty.
+	SaveBoardState func(boardID string, identity string, elements json.RawMessage) error
+	// GetAccess reports what the user with the given identity may do on
+	// the board now.
+	GetAccess func(boardID string, identity string) (Access, error)
 }
 
+// botIdentity is the session's own participant in the room.
+const botIdentity = "bot"
+
 type StreamTextData struct {
 	Type string      `json:"type"`
 	Data interface{} `json:"data"`
 }
 
+type outgoingText struct {
+	topic string
+	data  StreamTextData
+}
+
 type LiveKitSession struct {
 	userDetails     *repo.User
 	boardID         string
 	room            *lksdk.Room
-	handler         LivekitHandler
 	speechClient    *speech.Client
 	llmClient       llm.LLMClient
 	egressInfo      *livekit.EgressInfo
@@ -46,11 +62,29 @@ type LiveKitSession struct {
 	speechConfig    *config.SpeechConfig
 	llmConfig       *config.LLMConfig
 	awsConfig       *config.AWSConfig
+	boardConfig     *config.BoardConfig
+	limits          excalidraw.Limits
+	board           boardState
 	ctx             context.Context
 	cancel          context.CancelFunc
 	callbacks       SessionCallbacks
 	stopOnce        sync.Once
-	textStreamQueue chan StreamTextData
+	// presence is told when a participant other than the bot connects or
+	// disconnects. It is set by the Manager before Start.
+	presence        func(identity string, connected bool)
+	// voices holds each participant's speech pipeline, by identity.
+	voicesMu        sync.Mutex
+	voices          map[string]*participantVoice
+	// access caches each participant's access, by identity.
+	accessMu        sync.Mutex
+	access          map[string]Access
+	textStreamQueue chan outgoingText
+	// audioOut feeds the bot's published audio track.
+	audioOut        chan media.PCM16Sample
+	publishTrack    atomic.Pointer[lkmedia.PCMLocalTrack]
+	voiceReplies    atomic.Bool
+	// speakMu keeps one reply from talking over another.
+	speakMu         sync.Mutex
 	recordingURL    string
 	transcriptURL   string
 }
@@ -76,7 +110,7 @@ func NewLiveKitSession(
 		return nil, fmt.Errorf("failed to create LLM client: %w", err)
 	}
 
-	return &LiveKitSession{
+	session := &LiveKitSession{
 		userDetails:     userDetails,
 		boardID:         boardID,
 		lkConfig:        &cfg.LiveKit,
@@ -85,12 +119,23 @@ func NewLiveKitSession(
 		speechClient:    speechClient,
 		llmClient:       llmClient,
 		awsConfig:       &cfg.AWS,
+		boardConfig:     &cfg.Board,
+		limits: excalidraw.Limits{
+			MaxElements:   cfg.Limits.MaxElements,
+			MaxTextLength: cfg.Limits.MaxTextLength,
+		},
 		ctx:             ctx,
 		cancel:          cancel,
 		callbacks:       callbacks,
 		stopOnce:        sync.Once{},
-		textStreamQueue: make(chan StreamTextData, 100),
-	}, nil
+		voices:          make(map[string]*participantVoice),
+		access:          make(map[string]Access),
+		board:           boardState{pending: make(map[string]bool)},
+		textStreamQueue: make(chan outgoingText, 100),
+		audioOut:        make(chan media.PCM16Sample, 500),
+	}
+	session.voiceReplies.Store(cfg.Speech.VoiceReplies)
+	return session, nil
 }
 
 func (s *LiveKitSession) Start() error {
@@ -103,21 +148,17 @@ func (s *LiveKitSession) Start() error {
 func (s *LiveKitSession) Stop() error {
 	var stopErr error
 	s.stopOnce.Do(func() {
+		s.flushBoard()
 		s.cancel()
 		if s.egressInfo != nil {
 			if err := s.stopRecording(s.egressInfo.EgressId); err != nil {
 				stopErr = fmt.Errorf("failed to stop recording: %w", err)
 			}
 		}
-		if s.textStreamQueue != nil {
-			close(s.textStreamQueue)
-		}
 		if s.room != nil {
 			s.room.Disconnect()
 		}
-		if s.handler != nil {
-			s.handler.Close()
-		}
+		s.closeVoices()
 		if s.speechClient != nil {
 			s.speechClient.Close()
 		}
@@ -128,14 +169,30 @@ func (s *LiveKitSession) Stop() error {
 	return stopErr
 }
 
-func (s *LiveKitSession) GenerateUserToken() (string, error) {
+// Done is closed once the session has stopped.
+func (s *LiveKitSession) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+func (s *LiveKitSession) stopped() bool {
+	return s.ctx.Err() != nil
+}
+
+// GenerateUserToken returns a room token for user with the permissions their
+// access allows. Users who may not edit the board join without media publish
+// rights, though they may still send data such as chat messages.
+func (s *LiveKitSession) GenerateUserToken(user *repo.User, access Access) (string, error) {
+	permission := participantPermission(access)
 	at := auth.NewAccessToken(s.lkConfig.APIKey, s.lkConfig.APISecret)
 	grant := &auth.VideoGrant{
 		RoomJoin: true,
 		Room:     s.boardID,
 	}
+	grant.SetCanPublish(permission.CanPublish)
+	grant.SetCanPublishData(permission.CanPublishData)
 	at.SetVideoGrant(grant).
-		SetIdentity(s.userDetails.Name).
+		SetIdentity(user.ID).
+		SetName(user.Name).
 		SetValidFor(time.Hour)
 	token, err := at.ToJWT()
 	if err != nil {
@@ -144,68 +201,30 @@ func (s *LiveKitSession) GenerateUserToken() (string, error) {
 	return token, nil
 }
 
-func (s *LiveKitSession) HandleMute() error {
-	if s.handler == nil {
-		return nil
+func (s *LiveKitSession) connectBot() error {
+	if err := s.loadBoard(); err != nil {
+		return fmt.Errorf("failed to load board: %w", err)
 	}
-	return s.handler.OnMute()
-}
 
-func (s *LiveKitSession) HandleUnmute() error {
-	if s.handler == nil {
-		return nil
+	if err := s.connectToRoom(); err != nil {
+		return fmt.Errorf("failed to connect to room: %w", err)
 	}
-	return s.handler.OnUnmute()
-}
-
-func (s *LiveKitSession) connectBot() error {
-	audioWriterChan := make(chan media.PCM16Sample, 500)
-
-	sessionID := fmt.Sprintf("%s:%s", s.boardID, s.userDetails.ID)
 
-	handler, err := NewVoiceHandler(VoiceHandlerConfig{
-		SessionID:    sessionID,
-		BoardID:      s.boardID,
-		UserID:       s.userDetails.ID,
-		SpeechClient: s.speechClient,
-		LLMClient:    s.llmClient,
-		OnLLMResponse: func( response *llm.LLMResponse, err error) {
-			if err != nil {
-				logger.Errorw("LLM error", err)
-				if s.callbacks.OnLLMResponse != nil {
-					s.callbacks.OnLLMResponse(s.boardID, nil, err)
-				}
-				return
-			}
-
-			jsonData, err := json.MarshalIndent(response, "", "  ")
-			if err != nil {
-				logger.Errorw("Failed to marshal LLM response", err)
-				return
-			}
-
-			fmt.Println("LLM response", string(jsonData))
-
-			// s.textStreamQueue <- StreamTextData{
-			// 	Type: "canvas_update",
-			// 	Data: response,
-			// }
-		},
-		GetBoardState: s.callbacks.GetBoardState,
-	})
-	if err != nil {
-		close(audioWriterChan)
-		return fmt.Errorf("failed to create voice handler: %w", err)
+	if err := s.room.RegisterTextStreamHandler(TopicBoard, s.handleBoardStream); err != nil {
+		s.room.Disconnect()
+		return fmt.Errorf("failed to register board stream handler: %w", err)
+	}
+	if err := s.room.RegisterTextStreamHandler(TopicChat, s.handleChatStream); err != nil {
+		s.room.Disconnect()
+		return fmt.Errorf("failed to register chat stream handler: %w", err)
 	}
-	s.handler = handler
 
-	if err := s.connectToRoom(); err != nil {
-		s.handler.Close()
-		close(audioWriterChan)
-		return fmt.Errorf("failed to connect to room: %w", err)
+	// Participants already in the room are not announced as connecting.
+	for _, participant := range s.room.GetRemoteParticipants() {
+		s.notifyPresence(participant.Identity(), true)
 	}
 
-	go s.handlePublish(audioWriterChan)
+	go s.handlePublish()
 	go s.handleTextStreamQueue()
 
 	// egressInfo, err := s.startRecording()
@@ -222,7 +241,7 @@ func (s *LiveKitSession) connectToRoom() error {
 		APIKey:              s.lkConfig.APIKey,
 		APISecret:           s.lkConfig.APISecret,
 		RoomName:            s.boardID,
-		ParticipantIdentity: "bot",
+		ParticipantIdentity: botIdentity,
 	}, s.callbacksForRoom())
 	if err != nil {
 		return err
@@ -232,18 +251,20 @@ func (s *LiveKitSession) connectToRoom() error {
 }
 
 func (s *LiveKitSession) callbacksForRoom() *lksdk.RoomCallback {
-	var pcmRemoteTrack *lkmedia.PCMRemoteTrack
-
 	return &lksdk.RoomCallback{
 		ParticipantCallback: lksdk.ParticipantCallback{
 			OnTrackSubscribed: func(track *webrtc.TrackRemote, publication *lksdk.RemoteTrackPublication, rp *lksdk.RemoteParticipant) {
 				if publication.Kind() != lksdk.TrackKindAudio {
 					return
 				}
-				if pcmRemoteTrack != nil {
-					return
+				if err := s.addVoice(track, publication, rp); err != nil {
+					logger.Errorw("Failed to start voice pipeline", err, "participant", rp.Identity())
+				}
+			},
+			OnTrackUnsubscribed: func(track *webrtc.TrackRemote, publication *lksdk.RemoteTrackPublication, rp *lksdk.RemoteParticipant) {
+				if publication.Kind() == lksdk.TrackKindAudio {
+					s.removeVoice(rp.Identity())
 				}
-				pcmRemoteTrack, _ = s.handleSubscribe(track)
 			},
 			OnTrackMuted: func(pub lksdk.TrackPublication, p lksdk.Participant) {
 				// Handle track mute - close transcription session
@@ -252,8 +273,8 @@ func (s *LiveKitSession) callbacksForRoom() *lksdk.RoomCallback {
 				if pub.Kind() == lksdk.TrackKindAudio {
 					logger.Infow("Audio track muted", "participant", p.Identity())
 					go func() {
-						if err := s.HandleMute(); err != nil {
-							logger.Errorw("HandleMute failed", err)
+						if err := s.HandleMute(p.Identity()); err != nil {
+							logger.Errorw("HandleMute failed", err, "participant", p.Identity())
 						}
 					}()
 				}
@@ -263,41 +284,39 @@ func (s *LiveKitSession) callbacksForRoom() *lksdk.RoomCallback {
 				if pub.Kind() == lksdk.TrackKindAudio {
 					logger.Infow("Audio track unmuted", "participant", p.Identity())
 					go func() {
-						if err := s.HandleUnmute(); err != nil {
-							logger.Errorw("HandleUnmute failed", err)
+						if err := s.HandleUnmute(p.Identity()); err != nil {
+							logger.Errorw("HandleUnmute failed", err, "participant", p.Identity())
 						}
 					}()
 				}
 			},
 		},
+		OnParticipantConnected: func(participant *lksdk.RemoteParticipant) {
+			s.notifyPresence(participant.Identity(), true)
+		},
 		OnParticipantDisconnected: func(participant *lksdk.RemoteParticipant) {
-			s.Stop()
+			s.removeVoice(participant.Identity())
+			s.forgetAccess(participant.Identity())
+			s.notifyPresence(participant.Identity(), false)
 		},
 		OnDisconnected: func() {
-			if pcmRemoteTrack != nil {
-				pcmRemoteTrack.Close()
-				pcmRemoteTrack = nil
-			}
+			s.closeVoices()
 		},
 		OnDisconnectedWithReason: func(reason lksdk.DisconnectionReason) {
-			if pcmRemoteTrack != nil {
-				pcmRemoteTrack.Close()
-				pcmRemoteTrack = nil
-			}
+			s.closeVoices()
 		},
 	}
 }
 
-
-func (s *LiveKitSession) handlePublish(audioWriterChan chan media.PCM16Sample) {
-	publishTrack, err := lkmedia.NewPCMLocalTrack(24000, 1, logger.GetLogger())
+func (s *LiveKitSession) handlePublish() {
+	publishTrack, err := lkmedia.NewPCMLocalTrack(publishSampleRate, 1, logger.GetLogger())
 	if err != nil {
 		return
 	}
 	defer func() {
+		s.publishTrack.Store(nil)
 		publishTrack.ClearQueue()
 		publishTrack.Close()
-		close(audioWriterChan)
 	}()
 
 	// !FIXME
@@ -306,13 +325,11 @@ func (s *LiveKitSession) handlePublish(audioWriterChan chan media.PCM16Sample) {
 	}); err != nil {
 		return
 	}
+	s.publishTrack.Store(publishTrack)
 
 	for {
 		select {
-		case sample, ok := <-audioWriterChan:
-			if !ok {
-				return
-			}
+		case sample := <-s.audioOut:
 			if err := publishTrack.WriteSample(sample); err != nil {
 			}
 		case <-s.ctx.Done():
@@ -321,20 +338,31 @@ func (s *LiveKitSession) handlePublish(audioWriterChan chan media.PCM16Sample) {
 	}
 }
 
+func (s *LiveKitSession) notifyPresence(identity string, connected bool) {
+	if s.presence != nil {
+		s.presence(identity, connected)
+	}
+}
+
+// sendText queues data for topic. It is a no-op once the session has
+// stopped.
+func (s *LiveKitSession) sendText(topic string, data StreamTextData) {
+	select {
+	case s.textStreamQueue <- outgoingText{topic: topic, data: data}:
+	case <-s.ctx.Done():
+	}
+}
+
 func (s *LiveKitSession) handleTextStreamQueue() {
 	for {
 		select {
-		case data, ok := <-s.textStreamQueue:
-			if !ok {
-				// Channel closed, exit worker
-				return
-			}
-			marshalData, err := json.Marshal(data)
+		case text := <-s.textStreamQueue:
+			marshalData, err := json.Marshal(text.data)
 			if err != nil {
 				continue
 			}
 			s.room.LocalParticipant.SendText(string(marshalData), lksdk.StreamTextOptions{
-				Topic: "board",
+				Topic: text.topic,
 			})
 		case <-s.ctx.Done():
 			return
@@ -342,7 +370,7 @@ func (s *LiveKitSession) handleTextStreamQueue() {
 	}
 }
 
-func (s *LiveKitSession) handleSubscribe(track *webrtc.TrackRemote) (*lkmedia.PCMRemoteTrack, error) {
+func (s *LiveKitSession) handleSubscribe(track *webrtc.TrackRemote, handler LivekitHandler) (*lkmedia.PCMRemoteTrack, error) {
 	// Only process audio tracks
 	if track.Kind() != webrtc.RTPCodecTypeAudio {
 		return nil, fmt.Errorf("expected audio track, got %v", track.Kind())
@@ -352,7 +380,7 @@ func (s *LiveKitSession) handleSubscribe(track *webrtc.TrackRemote) (*lkmedia.PC
 		logger.Warnw("Received non-opus track", nil, "track", track.Codec().MimeType)
 	}
 
-	writer := NewRemoteTrackWriter(s.handler)
+	writer := NewRemoteTrackWriter(handler)
 	trackWriter, err := lkmedia.NewPCMRemoteTrack(track, writer, lkmedia.WithTargetSampleRate(16000))
 	if err != nil {
 		return nil, err
@@ -0,0 +1,70 @@
+package livekit
+
+import (
+	"errors"
+
+	"github.com/livekit/media-sdk"
+	"github.com/livekit/protocol/logger"
+)
+
+// publishSampleRate is the sample rate of the bot's published audio track.
+const publishSampleRate = 24000
+
+var errVoiceRepliesOff = errors.New("voice replies turned off")
+
+// speak reads text aloud on the bot's audio track. Replies are spoken one at
+// a time, in the order they arrive.
+func (s *LiveKitSession) speak(text string) {
+	if !s.voiceReplies.Load() {
+		return
+	}
+	s.speakMu.Lock()
+	defer s.speakMu.Unlock()
+
+	err := s.speechClient.Synthesize(s.ctx, s.boardID+":"+botIdentity, text, publishSampleRate, func(audioChunk []byte) error {
+		if !s.voiceReplies.Load() {
+			return errVoiceRepliesOff
+		}
+		select {
+		case s.audioOut <- bytesToPCM16(audioChunk):
+			return nil
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	})
+	if err != nil && !errors.Is(err, errVoiceRepliesOff) && !s.stopped() {
+		logger.Errorw("Speech synthesis failed", err, "boardID", s.boardID)
+	}
+}
+
+// VoiceReplies reports whether the bot speaks its replies.
+func (s *LiveKitSession) VoiceReplies() bool {
+	return s.voiceReplies.Load()
+}
+
+// SetVoiceReplies turns spoken replies on or off. Turning them off also cuts
+// short whatever the bot is saying.
+func (s *LiveKitSession) SetVoiceReplies(enabled bool) {
+	s.voiceReplies.Store(enabled)
+	if enabled {
+		return
+	}
+	for {
+		select {
+		case <-s.audioOut:
+		default:
+			if track := s.publishTrack.Load(); track != nil {
+				track.ClearQueue()
+			}
+			return
+		}
+	}
+}
+
+func bytesToPCM16(audio []byte) media.PCM16Sample {
+	sample := make(media.PCM16Sample, len(audio)/2)
+	for i := range sample {
+		sample[i] = int16(audio[i*2]) | int16(audio[i*2+1])<<8
+	}
+	return sample
+}
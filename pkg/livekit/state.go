@@ -0,0 +1,57 @@
+package livekit
+
+import (
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// ParticipantState describes a user connected to a board's room.
+type ParticipantState struct {
+	Identity string
+	Name     string
+	// Muted is set when the participant is not sending audio: their
+	// microphone is muted or was never published.
+	Muted      bool
+	CanPublish bool
+}
+
+// SessionState is a snapshot of a board's live session.
+type SessionState struct {
+	// BotState is the bot's connection to the room: "connected",
+	// "reconnecting" or "disconnected".
+	BotState     string
+	Participants []ParticipantState
+	// VoiceReplies is set when the bot speaks its replies.
+	VoiceReplies bool
+}
+
+// State reports the bot's connection and who else is in the room.
+func (s *LiveKitSession) State() SessionState {
+	state := SessionState{
+		BotState:     string(lksdk.ConnectionStateDisconnected),
+		Participants: []ParticipantState{},
+		VoiceReplies: s.voiceReplies.Load(),
+	}
+	if s.room == nil || s.stopped() {
+		return state
+	}
+	state.BotState = string(s.room.ConnectionState())
+	for _, participant := range s.room.GetRemoteParticipants() {
+		muted := true
+		for _, publication := range participant.TrackPublications() {
+			if publication.Kind() == lksdk.TrackKindAudio && !publication.IsMuted() {
+				muted = false
+			}
+		}
+		canPublish := false
+		if permissions := participant.Permissions(); permissions != nil {
+			canPublish = permissions.CanPublish
+		}
+		state.Participants = append(state.Participants, ParticipantState{
+			Identity:   participant.Identity(),
+			Name:       participant.Name(),
+			Muted:      muted,
+			CanPublish: canPublish,
+		})
+	}
+	return state
+}
@@ -2,47 +2,39 @@ package livekit
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync"
 
-	"draw/pkg/llm"
 	"draw/pkg/speech"
 
 	"github.com/livekit/media-sdk"
 	"github.com/livekit/protocol/logger"
 )
 
-type LLMResponseCallback func(response *llm.LLMResponse, err error)
-
-type GetBoardStateFunc func(boardID string) (json.RawMessage, error)
-
 type VoiceHandler struct {
 	sessionID             string
 	boardID               string
-	userID                string
+	identity              string
+	name                  string
 	speechClient          *speech.Client
-	llmClient             llm.LLMClient
 	session               *speech.TranscribeSession
 	ctx                   context.Context
 	cancel                context.CancelFunc
 	mu                    sync.Mutex
 	isMuted               bool
 	onTranscribe          TranscriptionCallback
-	onLLMResponse         LLMResponseCallback
-	getBoardState         GetBoardStateFunc
 	transcriptionCallback speech.TranscriptionCallback
 }
 
 type VoiceHandlerConfig struct {
-	SessionID     string
-	BoardID       string
-	UserID        string
-	SpeechClient  *speech.Client
-	LLMClient     llm.LLMClient
-	OnTranscribe  TranscriptionCallback
-	OnLLMResponse LLMResponseCallback
-	GetBoardState GetBoardStateFunc
+	SessionID string
+	BoardID   string
+	// Identity and Name are the room participant whose audio this handles.
+	Identity     string
+	Name         string
+	SpeechClient *speech.Client
+	// OnTranscribe receives each finished utterance.
+	OnTranscribe TranscriptionCallback
 }
 
 func NewVoiceHandler(cfg VoiceHandlerConfig) (*VoiceHandler, error) {
@@ -58,17 +50,15 @@ func NewVoiceHandler(cfg VoiceHandlerConfig) (*VoiceHandler, error) {
 	
 	
 	handler := &VoiceHandler{
-		sessionID:     cfg.SessionID,
-		boardID:       cfg.BoardID,
-		userID:        cfg.UserID,
-		speechClient:  cfg.SpeechClient,
-		llmClient:     cfg.LLMClient,
-		ctx:           ctx,
-		cancel:        cancel,
-		isMuted:       true, 
-		onTranscribe:  cfg.OnTranscribe,
-		onLLMResponse: cfg.OnLLMResponse,
-		getBoardState: cfg.GetBoardState,
+		sessionID:    cfg.SessionID,
+		boardID:      cfg.BoardID,
+		identity:     cfg.Identity,
+		name:         cfg.Name,
+		speechClient: cfg.SpeechClient,
+		ctx:          ctx,
+		cancel:       cancel,
+		isMuted:      true, 
+		onTranscribe: cfg.OnTranscribe,
 	}
 
 	transcriptionCallback := func(transcription string, err error) {
@@ -78,9 +68,6 @@ func NewVoiceHandler(cfg VoiceHandlerConfig) (*VoiceHandler, error) {
 			}
 			return
 		}
-		if handler.llmClient != nil {
-			go handler.handleLLMResponse(transcription)
-		}
 		if handler.onTranscribe != nil {
 			handler.onTranscribe(handler.sessionID, transcription, nil)
 		}
@@ -173,24 +160,6 @@ func (h *VoiceHandler) Close() error {
 	return nil
 }
 
-func (h *VoiceHandler) handleLLMResponse(transcription string) {
-	response, err := h.llmClient.GenerateResponse(context.Background(), transcription)
-	if err != nil {
-		if h.onLLMResponse != nil {
-			h.onLLMResponse(nil, err)
-		}
-		return
-	}
-
-	fmt.Println("LLM response", response)
-
-	if h.onLLMResponse != nil {
-		fmt.Println("Calling on LLM response callback", response)
-		h.onLLMResponse(response, nil)
-	}
-}
-
-
 func pcm16ToBytes(sample media.PCM16Sample) []byte {
 	bytes := make([]byte, len(sample)*2)
 	for i, s := range sample {
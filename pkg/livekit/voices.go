@@ -0,0 +1,158 @@
+package livekit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/livekit/protocol/logger"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+	lkmedia "github.com/livekit/server-sdk-go/v2/pkg/media"
+	"github.com/pion/webrtc/v4"
+)
+
+// TopicRoom carries what the bot tells the room about the conversation, such
+// as transcripts of what each participant said.
+const TopicRoom = "room"
+
+// StreamTypeTranscript tags a TranscriptData on the room topic.
+const StreamTypeTranscript = "transcript"
+
+// TranscriptData is one finished utterance.
+type TranscriptData struct {
+	// Role is "user" for participants and "ai" for the bot.
+	Role     string `json:"role"`
+	Identity string `json:"identity"`
+	Name     string `json:"name"`
+	Content  string `json:"content"`
+	// Timestamp identifies the utterance; a later message with the same
+	// speaker and timestamp replaces it.
+	Timestamp string `json:"timestamp"`
+	// ReplyTo is the identity of the participant the bot is answering.
+	ReplyTo string `json:"replyTo,omitempty"`
+}
+
+// participantVoice is the speech pipeline for one participant's microphone.
+type participantVoice struct {
+	handler *VoiceHandler
+	track   *lkmedia.PCMRemoteTrack
+}
+
+func (v *participantVoice) close() {
+	v.track.Close()
+	v.handler.Close()
+}
+
+// addVoice starts transcribing a participant's audio track, replacing any
+// pipeline they already had.
+func (s *LiveKitSession) addVoice(track *webrtc.TrackRemote, publication *lksdk.RemoteTrackPublication, rp *lksdk.RemoteParticipant) error {
+	identity := rp.Identity()
+	// The speech session id is reused, so the old pipeline has to be gone
+	// before the new one opens it.
+	s.removeVoice(identity)
+
+	handler, err := NewVoiceHandler(VoiceHandlerConfig{
+		SessionID:    fmt.Sprintf("%s:%s", s.boardID, identity),
+		BoardID:      s.boardID,
+		Identity:     identity,
+		Name:         rp.Name(),
+		SpeechClient: s.speechClient,
+		OnTranscribe: func(sessionID string, transcription string, err error) {
+			if err != nil {
+				logger.Errorw("Transcription failed", err, "sessionID", sessionID)
+				return
+			}
+			s.sendText(TopicRoom, StreamTextData{
+				Type: StreamTypeTranscript,
+				Data: TranscriptData{
+					Role:      "user",
+					Identity:  identity,
+					Name:      rp.Name(),
+					Content:   transcription,
+					Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+				},
+			})
+			go s.handleRequest(assistantRequest{
+				identity: identity,
+				name:     rp.Name(),
+				text:     transcription,
+				topic:    TopicRoom,
+			})
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create voice handler: %w", err)
+	}
+	remoteTrack, err := s.handleSubscribe(track, handler)
+	if err != nil {
+		handler.Close()
+		return err
+	}
+	voice := &participantVoice{handler: handler, track: remoteTrack}
+
+	s.voicesMu.Lock()
+	if s.stopped() {
+		s.voicesMu.Unlock()
+		voice.close()
+		return nil
+	}
+	s.voices[identity] = voice
+	s.voicesMu.Unlock()
+
+	// A track published unmuted sends no unmute event.
+	if !publication.IsMuted() {
+		if err := handler.OnUnmute(); err != nil {
+			return fmt.Errorf("failed to start transcription: %w", err)
+		}
+	}
+	return nil
+}
+
+// removeVoice stops transcribing a participant.
+func (s *LiveKitSession) removeVoice(identity string) {
+	s.voicesMu.Lock()
+	voice, ok := s.voices[identity]
+	delete(s.voices, identity)
+	s.voicesMu.Unlock()
+	if ok {
+		voice.close()
+	}
+}
+
+// closeVoices stops every participant's pipeline.
+func (s *LiveKitSession) closeVoices() {
+	s.voicesMu.Lock()
+	voices := s.voices
+	s.voices = make(map[string]*participantVoice)
+	s.voicesMu.Unlock()
+	for _, voice := range voices {
+		voice.close()
+	}
+}
+
+func (s *LiveKitSession) voice(identity string) (*VoiceHandler, bool) {
+	s.voicesMu.Lock()
+	defer s.voicesMu.Unlock()
+	voice, ok := s.voices[identity]
+	if !ok {
+		return nil, false
+	}
+	return voice.handler, true
+}
+
+// HandleMute ends the participant's transcription session.
+func (s *LiveKitSession) HandleMute(identity string) error {
+	handler, ok := s.voice(identity)
+	if !ok {
+		return nil
+	}
+	return handler.OnMute()
+}
+
+// HandleUnmute starts a new transcription session for the participant.
+func (s *LiveKitSession) HandleUnmute(identity string) error {
+	handler, ok := s.voice(identity)
+	if !ok {
+		return nil
+	}
+	return handler.OnUnmute()
+}
@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BoardOpType is the kind of change a BoardOp asks for.
+type BoardOpType string
+
+const (
+	BoardOpAddShape BoardOpType = "add_shape"
+	BoardOpAddText  BoardOpType = "add_text"
+	BoardOpConnect  BoardOpType = "connect"
+	BoardOpMove     BoardOpType = "move"
+	BoardOpRestyle  BoardOpType = "restyle"
+	BoardOpDelete   BoardOpType = "delete"
+)
+
+// BoardOp is one change the model asks to make to the board. Which fields
+// are read depends on Type:
+//
+//   - add_shape: Shape, X, Y, optional Width, Height, Text and colors
+//   - add_text: Text, X, Y and optional StrokeColor
+//   - connect: From, To and optional Text as the arrow label
+//   - move: ID, X, Y
+//   - restyle: ID and at least one color
+//   - delete: ID
+//
+// Adds may set ID to a name of the model's choosing so that later operations
+// in the same reply can refer to the new element.
+type BoardOp struct {
+	Type            BoardOpType `json:"type"`
+	ID              string      `json:"id,omitempty"`
+	Shape           string      `json:"shape,omitempty"`
+	Text            string      `json:"text,omitempty"`
+	X               float64     `json:"x,omitempty"`
+	Y               float64     `json:"y,omitempty"`
+	Width           float64     `json:"width,omitempty"`
+	Height          float64     `json:"height,omitempty"`
+	From            string      `json:"from,omitempty"`
+	To              string      `json:"to,omitempty"`
+	StrokeColor     string      `json:"strokeColor,omitempty"`
+	BackgroundColor string      `json:"backgroundColor,omitempty"`
+}
+
+// boardInstructions is the system prompt that asks for replies ParseReply
+// understands.
+const boardInstructions = `You are an assistant on a shared whiteboard. Several people talk to you; each request starts with the name of who made it.
+
+Reply with a single JSON object and nothing else:
+{"reply": "<short answer to the person>", "operations": [<board operations>]}
+
+Leave "operations" empty unless you were asked to change the board. Each operation is one of:
+{"type": "add_shape", "id": "<new name>", "shape": "rectangle|ellipse|diamond", "x": 0, "y": 0, "width": 160, "height": 80, "text": "<label>", "strokeColor": "#1e1e1e", "backgroundColor": "#a5d8ff"}
+{"type": "add_text", "id": "<new name>", "x": 0, "y": 0, "text": "<text>"}
+{"type": "connect", "from": "<id>", "to": "<id>", "text": "<optional label>"}
+{"type": "move", "id": "<id>", "x": 0, "y": 0}
+{"type": "restyle", "id": "<id>", "strokeColor": "#e03131", "backgroundColor": "#ffc9c9"}
+{"type": "delete", "id": "<id>"}
+
+Refer to existing elements by the ids listed with the board, and to elements you add by the id you gave them. Colors are hex codes or "transparent".`
+
+// reply is the JSON object the model is asked to answer with.
+type reply struct {
+	Reply      string    `json:"reply"`
+	Operations []BoardOp `json:"operations"`
+}
+
+// ParseReply splits a model's answer into the text for the user and the
+// board operations it asked for. Models that ignore the format are answered
+// with their text as is and no operations.
+func ParseReply(text string) (string, []BoardOp) {
+	text = strings.TrimSpace(text)
+	var parsed reply
+	if err := json.Unmarshal([]byte(text), &parsed); err != nil {
+		return text, nil
+	}
+	return strings.TrimSpace(parsed.Reply), parsed.Operations
+}
+
+// WithBoard prepends a description of the board to a request so the model
+// can refer to its elements.
+func WithBoard(board string, text string) string {
+	if board == "" {
+		return "The board is empty.\n\n" + text
+	}
+	return fmt.Sprintf("The board has these elements:\n%s\n\n%s", board, text)
+}
@@ -0,0 +1,53 @@
+package llm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReply(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantReply string
+		wantOps   []BoardOp
+	}{
+		{
+			name:      "reply only",
+			text:      `{"reply": "Hi Ada!", "operations": []}`,
+			wantReply: "Hi Ada!",
+			wantOps:   []BoardOp{},
+		},
+		{
+			name:      "operations",
+			text:      `{"reply": "Done.", "operations": [{"type": "add_shape", "id": "a", "shape": "ellipse", "x": 10, "y": 20, "text": "Start"}, {"type": "connect", "from": "a", "to": "xyz"}]}`,
+			wantReply: "Done.",
+			wantOps: []BoardOp{
+				{Type: BoardOpAddShape, ID: "a", Shape: "ellipse", X: 10, Y: 20, Text: "Start"},
+				{Type: BoardOpConnect, From: "a", To: "xyz"},
+			},
+		},
+		{
+			name:      "plain text",
+			text:      "  Sure, here you go.\n",
+			wantReply: "Sure, here you go.",
+		},
+		{
+			name:      "wrong shape",
+			text:      `{"reply": "ok", "operations": "none"}`,
+			wantReply: `{"reply": "ok", "operations": "none"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotReply, gotOps := ParseReply(tt.text)
+			if gotReply != tt.wantReply {
+				t.Errorf("ParseReply() reply = %q, want %q", gotReply, tt.wantReply)
+			}
+			if !reflect.DeepEqual(gotOps, tt.wantOps) {
+				t.Errorf("ParseReply() operations = %+v, want %+v", gotOps, tt.wantOps)
+			}
+		})
+	}
+}
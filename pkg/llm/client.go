@@ -9,6 +9,10 @@ import (
 type LLMResponse struct {
 	Response  string             `json:"response"`
 	Timestamp time.Time          `json:"timestamp"`
+	// Operations are the board changes the model asked for.
+	Operations []BoardOp `json:"operations,omitempty"`
+	// Speaker is the identity of the participant whose request this answers.
+	Speaker string `json:"speaker,omitempty"`
 }
 
 type LLMClient interface {
@@ -16,6 +20,15 @@ type LLMClient interface {
 	Close() error
 }	
 
+// Attribute prefixes a request with who made it, so a model serving a room
+// of people can tell them apart.
+func Attribute(name string, text string) string {
+	if name == "" {
+		return text
+	}
+	return fmt.Sprintf("%s says: %s", name, text)
+}
+
 type LLMProvider string
 
 const (
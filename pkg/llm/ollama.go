@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -93,19 +94,19 @@ func (c *OllamaLLMClient) GenerateResponse(ctx context.Context, prompt string) (
 }
 
 func (c *OllamaLLMClient) generateResponseSync(prompt string) (*LLMResponse, error) {
-	prompt = "You are a helpful assistant. Respond to the user's prompt in a friendly and helpful manner. The user's prompt is: " + prompt	
-
 	req := &api.GenerateRequest{
 		Model:  c.model,
+		System: boardInstructions,
 		Prompt: prompt,
+		Format: json.RawMessage(`"json"`),
 		Stream: new(bool),
 		Options: map[string]any{
 			"temperature": 0.1,
-			"num_predict": 200,
+			"num_predict": 512,
 		},
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	var fullResponse strings.Builder
@@ -117,11 +118,12 @@ func (c *OllamaLLMClient) generateResponseSync(prompt string) (*LLMResponse, err
 		return nil, fmt.Errorf("ollama generate error: %w", err)
 	}
 
-	responseText := strings.TrimSpace(fullResponse.String())
+	responseText, operations := ParseReply(fullResponse.String())
 
 	return &LLMResponse{
-		Response:  responseText,
-		Timestamp: time.Now(),
+		Response:   responseText,
+		Operations: operations,
+		Timestamp:  time.Now(),
 	}, nil
 }
 
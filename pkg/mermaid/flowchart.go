@@ -0,0 +1,248 @@
+package mermaid
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// flowchartKeywords start statements that carry no nodes or links.
+var flowchartKeywords = map[string]bool{
+	"end":       true,
+	"classDef":  true,
+	"class":     true,
+	"style":     true,
+	"linkStyle": true,
+	"click":     true,
+	"direction": true,
+}
+
+// nodeShapes maps node delimiters to shapes, longest opening first.
+var nodeShapes = []struct {
+	open, close string
+	shape       Shape
+}{
+	{"(((", ")))", ShapeCircle},
+	{"((", "))", ShapeCircle},
+	{"([", "])", ShapeRounded},
+	{"[[", "]]", ShapeRectangle},
+	{"[(", ")]", ShapeRounded},
+	{"{{", "}}", ShapeDiamond},
+	{"[/", "/]", ShapeRectangle},
+	{"[/", `\]`, ShapeRectangle},
+	{`[\`, `\]`, ShapeRectangle},
+	{`[\`, "/]", ShapeRectangle},
+	{"(", ")", ShapeRounded},
+	{"[", "]", ShapeRectangle},
+	{"{", "}", ShapeDiamond},
+	{">", "]", ShapeRectangle},
+}
+
+var (
+	nodeID = regexp.MustCompile(`^[\p{L}\p{N}_]+`)
+	// plainLink matches "-->", "---", "-.->", "==>", "<-->", "--o" and
+	// friends, with an optional |label|.
+	plainLink = regexp.MustCompile(`^(<)?(-{2,}|-\.+-|={2,})(>|o|x)?\s*(?:\|([^|]*)\|)?`)
+	// textLink matches a link with its label inline: "-- yes -->".
+	textLink = regexp.MustCompile(`^(<)?(--|-\.|==)\s*([^|]+?)\s*(-{2,}|\.+-|={2,})(>|o|x)?`)
+)
+
+func parseFlowchart(lines []string, first int, direction string) (*Diagram, error) {
+	p := &flowchartParser{
+		diagram: &Diagram{Kind: KindFlowchart, Direction: direction},
+		nodes:   map[string]int{},
+	}
+	for i := first; i < len(lines); i++ {
+		for _, statement := range splitStatements(lines[i]) {
+			if err := p.statement(statement); err != nil {
+				return nil, &SyntaxError{Line: i + 1, Msg: err.Error()}
+			}
+		}
+	}
+	return p.diagram, nil
+}
+
+// splitStatements splits a line on semicolons outside labels.
+func splitStatements(line string) []string {
+	var statements []string
+	depth, quoted, start := 0, false, 0
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case strings.ContainsRune("[({", r):
+			depth++
+		case strings.ContainsRune("])}", r) && depth > 0:
+			depth--
+		case r == ';' && depth == 0:
+			statements = append(statements, line[start:i])
+			start = i + 1
+		}
+	}
+	statements = append(statements, line[start:])
+
+	out := statements[:0]
+	for _, statement := range statements {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			out = append(out, statement)
+		}
+	}
+	return out
+}
+
+type flowchartParser struct {
+	diagram *Diagram
+	nodes   map[string]int
+	s       string
+}
+
+func (p *flowchartParser) statement(statement string) error {
+	keyword := strings.Fields(statement)[0]
+	if flowchartKeywords[keyword] {
+		return nil
+	}
+	if keyword == "subgraph" {
+		// Subgraph boundaries are not drawn; the nodes inside still are.
+		return nil
+	}
+
+	p.s = statement
+	from, err := p.nodeGroup()
+	if err != nil {
+		return err
+	}
+	for {
+		p.s = strings.TrimSpace(p.s)
+		if p.s == "" {
+			return nil
+		}
+		edge, err := p.link()
+		if err != nil {
+			return err
+		}
+		to, err := p.nodeGroup()
+		if err != nil {
+			return err
+		}
+		for _, a := range from {
+			for _, b := range to {
+				e := edge
+				e.From, e.To = a, b
+				p.diagram.Edges = append(p.diagram.Edges, e)
+			}
+		}
+		from = to
+	}
+}
+
+// nodeGroup parses "A", "A[label]" or "A & B[label] & C".
+func (p *flowchartParser) nodeGroup() ([]string, error) {
+	var ids []string
+	for {
+		p.s = strings.TrimSpace(p.s)
+		id, err := p.node()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		p.s = strings.TrimSpace(p.s)
+		if !strings.HasPrefix(p.s, "&") {
+			return ids, nil
+		}
+		p.s = p.s[1:]
+	}
+}
+
+func (p *flowchartParser) node() (string, error) {
+	id := nodeID.FindString(p.s)
+	if id == "" {
+		return "", fmt.Errorf("expected a node id at %q", p.s)
+	}
+	p.s = p.s[len(id):]
+
+	label, shape, hasShape := id, ShapeRectangle, false
+	for _, candidate := range nodeShapes {
+		if !strings.HasPrefix(p.s, candidate.open) {
+			continue
+		}
+		rest := p.s[len(candidate.open):]
+		text, after, ok := cutLabel(rest, candidate.close)
+		if !ok {
+			continue
+		}
+		label, shape, hasShape = cleanLabel(text), candidate.shape, true
+		p.s = after
+		break
+	}
+	if !hasShape && strings.ContainsAny(p.s[:min(1, len(p.s))], "[({>") {
+		return "", fmt.Errorf("unterminated label for node %q", id)
+	}
+	if strings.HasPrefix(p.s, ":::") {
+		class := nodeID.FindString(p.s[3:])
+		p.s = p.s[3+len(class):]
+	}
+
+	if i, ok := p.nodes[id]; ok {
+		if hasShape {
+			p.diagram.Nodes[i].Label = label
+			p.diagram.Nodes[i].Shape = shape
+		}
+		return id, nil
+	}
+	p.nodes[id] = len(p.diagram.Nodes)
+	p.diagram.Nodes = append(p.diagram.Nodes, Node{ID: id, Label: label, Shape: shape})
+	return id, nil
+}
+
+// cutLabel splits s at the close delimiter, skipping over a quoted label.
+func cutLabel(s, close string) (label, rest string, ok bool) {
+	from := 0
+	if strings.HasPrefix(s, `"`) {
+		end := strings.Index(s[1:], `"`)
+		if end < 0 {
+			return "", "", false
+		}
+		from = end + 2
+	}
+	end := strings.Index(s[from:], close)
+	if end < 0 {
+		return "", "", false
+	}
+	end += from
+	return s[:end], s[end+len(close):], true
+}
+
+func (p *flowchartParser) link() (Edge, error) {
+	if m := plainLink.FindStringSubmatch(p.s); m != nil && (len(m[2]) > 2 || m[3] != "") {
+		p.s = p.s[len(m[0]):]
+		return newEdge(m[1], m[2], m[3], m[4]), nil
+	}
+	if m := textLink.FindStringSubmatch(p.s); m != nil {
+		p.s = p.s[len(m[0]):]
+		return newEdge(m[1], m[2]+m[4], m[5], m[3]), nil
+	}
+	return Edge{}, fmt.Errorf("expected a link at %q", p.s)
+}
+
+func newEdge(start, line, end, label string) Edge {
+	return Edge{
+		Label:     cleanLabel(label),
+		Dashed:    strings.Contains(line, "."),
+		Thick:     strings.HasPrefix(line, "="),
+		StartHead: arrowhead(start),
+		EndHead:   arrowhead(end),
+	}
+}
+
+func arrowhead(marker string) string {
+	switch marker {
+	case ">", "<":
+		return "arrow"
+	case "o":
+		return "dot"
+	case "x":
+		return "bar"
+	}
+	return ""
+}
@@ -0,0 +1,427 @@
+package mermaid
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"draw/pkg/excalidraw"
+)
+
+// Layout metrics, in scene units. Text is measured approximately from the
+// number of characters since the frontend picks the final font.
+const (
+	fontSize       = 20
+	charWidth      = fontSize * 0.6
+	lineHeight     = fontSize * 1.25
+	nodeMinWidth   = 120
+	nodeMinHeight  = 60
+	nodePadding    = 40
+	rankGap        = 100
+	nodeGap        = 50
+	participantGap = 80
+	rowHeight      = 60
+	selfLoopWidth  = 50
+	noteColor      = "#ffec99"
+)
+
+// Elements lays the diagram out as Excalidraw skeleton elements with its top
+// left corner at the origin. Shapes carry their text as labels and arrows
+// reference the shapes they connect, as the frontend expects. newID supplies
+// element ids.
+func Elements(d *Diagram, newID func() string) []excalidraw.Element {
+	var elements []excalidraw.Element
+	if d.Kind == KindSequence {
+		elements = sequenceElements(d, newID)
+	} else {
+		elements = flowchartElements(d, newID)
+	}
+	if minX, minY, _, _, ok := excalidraw.Bounds(elements); ok {
+		excalidraw.Translate(elements, -minX, -minY)
+	}
+	return elements
+}
+
+func textSize(text string) (float64, float64) {
+	lines := strings.Split(text, "\n")
+	longest := 0
+	for _, line := range lines {
+		longest = max(longest, utf8.RuneCountInString(line))
+	}
+	return float64(longest) * charWidth, float64(len(lines)) * lineHeight
+}
+
+type box struct{ x, y, w, h float64 }
+
+func (b box) center() (float64, float64) { return b.x + b.w/2, b.y + b.h/2 }
+
+// border returns where the segment from the box center towards (tx, ty)
+// leaves the box.
+func (b box) border(tx, ty float64) (float64, float64) {
+	cx, cy := b.center()
+	dx, dy := tx-cx, ty-cy
+	if dx == 0 && dy == 0 {
+		return cx, cy
+	}
+	t := math.Inf(1)
+	if dx != 0 {
+		t = math.Min(t, b.w/2/math.Abs(dx))
+	}
+	if dy != 0 {
+		t = math.Min(t, b.h/2/math.Abs(dy))
+	}
+	return cx + dx*t, cy + dy*t
+}
+
+func flowchartElements(d *Diagram, newID func() string) []excalidraw.Element {
+	index := make(map[string]int, len(d.Nodes))
+	for i, node := range d.Nodes {
+		index[node.ID] = i
+	}
+	layers := rankNodes(d, index)
+	horizontal := d.Direction == "LR" || d.Direction == "RL"
+
+	boxes := make([]box, len(d.Nodes))
+	for i, node := range d.Nodes {
+		w, h := textSize(node.Label)
+		w, h = math.Max(nodeMinWidth, w+nodePadding), math.Max(nodeMinHeight, h+nodePadding/2)
+		switch node.Shape {
+		case ShapeDiamond:
+			w, h = w*1.5, h*1.5
+		case ShapeCircle:
+			w, h = w*1.3, h*1.3
+		}
+		boxes[i] = box{w: w, h: h}
+	}
+
+	// along runs with the flow, cross across it.
+	along := 0.0
+	for _, layer := range layers {
+		depth, breadth := 0.0, 0.0
+		for _, n := range layer {
+			b := boxes[n]
+			if horizontal {
+				depth, breadth = math.Max(depth, b.w), breadth+b.h
+			} else {
+				depth, breadth = math.Max(depth, b.h), breadth+b.w
+			}
+		}
+		breadth += nodeGap * float64(len(layer)-1)
+		cross := -breadth / 2
+		for _, n := range layer {
+			b := &boxes[n]
+			if horizontal {
+				b.x, b.y = along+(depth-b.w)/2, cross
+				cross += b.h + nodeGap
+			} else {
+				b.x, b.y = cross, along+(depth-b.h)/2
+				cross += b.w + nodeGap
+			}
+		}
+		along += depth + rankGap
+	}
+	for i := range boxes {
+		switch d.Direction {
+		case "BT":
+			boxes[i].y = -boxes[i].y - boxes[i].h
+		case "RL":
+			boxes[i].x = -boxes[i].x - boxes[i].w
+		}
+	}
+
+	ids := make([]string, len(d.Nodes))
+	elements := make([]excalidraw.Element, 0, len(d.Nodes)+len(d.Edges))
+	for i, node := range d.Nodes {
+		ids[i] = newID()
+		b := boxes[i]
+		element := excalidraw.Element{
+			ID:     ids[i],
+			Type:   excalidraw.TypeRectangle,
+			X:      b.x,
+			Y:      b.y,
+			Width:  b.w,
+			Height: b.h,
+			Label:  &excalidraw.Label{Text: node.Label},
+		}
+		switch node.Shape {
+		case ShapeRounded:
+			element.Roundness = &excalidraw.Roundness{Type: 3}
+		case ShapeCircle:
+			element.Type = excalidraw.TypeEllipse
+		case ShapeDiamond:
+			element.Type = excalidraw.TypeDiamond
+		}
+		elements = append(elements, element)
+	}
+
+	for _, edge := range d.Edges {
+		from, to := index[edge.From], index[edge.To]
+		var points []excalidraw.Point
+		var x, y float64
+		if from == to {
+			b := boxes[from]
+			x, y = b.x+b.w, b.y+b.h/4
+			points = loopPoints(b.h / 2)
+		} else {
+			tx, ty := boxes[to].center()
+			x, y = boxes[from].border(tx, ty)
+			fx, fy := boxes[from].center()
+			ex, ey := boxes[to].border(fx, fy)
+			points = []excalidraw.Point{{0, 0}, {ex - x, ey - y}}
+		}
+		arrow := arrowElement(newID(), x, y, points, ids[from], ids[to], edge.Label, edge.StartHead, edge.EndHead)
+		if edge.Dashed {
+			arrow.StrokeStyle = "dashed"
+		}
+		if edge.Thick {
+			arrow.StrokeWidth = 4
+		}
+		elements = append(elements, arrow)
+	}
+	return elements
+}
+
+// rankNodes assigns every node to a layer by its longest path from a source,
+// ignoring edges that close a cycle, then orders each layer to reduce
+// crossings.
+func rankNodes(d *Diagram, index map[string]int) [][]int {
+	n := len(d.Nodes)
+	out := make([][]int, n)
+	for _, edge := range d.Edges {
+		out[index[edge.From]] = append(out[index[edge.From]], index[edge.To])
+	}
+
+	// Depth-first search drops back edges so the rest is acyclic.
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make([]int, n)
+	forward := make([][]int, n)
+	var visit func(int)
+	visit = func(u int) {
+		state[u] = active
+		for _, v := range out[u] {
+			switch state[v] {
+			case unvisited:
+				forward[u] = append(forward[u], v)
+				visit(v)
+			case done:
+				forward[u] = append(forward[u], v)
+			}
+		}
+		state[u] = done
+	}
+	for u := 0; u < n; u++ {
+		if state[u] == unvisited {
+			visit(u)
+		}
+	}
+
+	indegree := make([]int, n)
+	for u := range forward {
+		for _, v := range forward[u] {
+			indegree[v]++
+		}
+	}
+	rank := make([]int, n)
+	var queue []int
+	for u := 0; u < n; u++ {
+		if indegree[u] == 0 {
+			queue = append(queue, u)
+		}
+	}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range forward[u] {
+			rank[v] = max(rank[v], rank[u]+1)
+			if indegree[v]--; indegree[v] == 0 {
+				queue = append(queue, v)
+			}
+		}
+	}
+
+	var layers [][]int
+	for u := 0; u < n; u++ {
+		for len(layers) <= rank[u] {
+			layers = append(layers, nil)
+		}
+		layers[rank[u]] = append(layers[rank[u]], u)
+	}
+
+	// One barycenter sweep: order each layer by the mean position of its
+	// parents in the layer above.
+	position := make([]float64, n)
+	parents := make([][]int, n)
+	for u := range forward {
+		for _, v := range forward[u] {
+			parents[v] = append(parents[v], u)
+		}
+	}
+	for _, layer := range layers {
+		weight := make(map[int]float64, len(layer))
+		for i, u := range layer {
+			weight[u] = float64(i) - float64(len(layer)-1)/2
+			if len(parents[u]) > 0 {
+				sum := 0.0
+				for _, p := range parents[u] {
+					sum += position[p]
+				}
+				weight[u] = sum / float64(len(parents[u]))
+			}
+		}
+		sort.SliceStable(layer, func(i, j int) bool { return weight[layer[i]] < weight[layer[j]] })
+		for i, u := range layer {
+			position[u] = float64(i) - float64(len(layer)-1)/2
+		}
+	}
+	return layers
+}
+
+func sequenceElements(d *Diagram, newID func() string) []excalidraw.Element {
+	index := make(map[string]int, len(d.Participants))
+	boxes := make([]box, len(d.Participants))
+	x := 0.0
+	for i, participant := range d.Participants {
+		index[participant.ID] = i
+		w, h := textSize(participant.Label)
+		boxes[i] = box{x: x, w: math.Max(nodeMinWidth+30, w+nodePadding), h: math.Max(nodeMinHeight, h+nodePadding/2)}
+		x += boxes[i].w + participantGap
+	}
+	lifeline := func(id string) float64 {
+		cx, _ := boxes[index[id]].center()
+		return cx
+	}
+
+	var rows []excalidraw.Element
+	y := float64(nodeMinHeight + rowHeight)
+	for _, participant := range boxes {
+		y = math.Max(y, participant.h+rowHeight)
+	}
+	for _, step := range d.Steps {
+		if message := step.Message; message != nil {
+			self := message.From == message.To
+			_, textHeight := textSize(message.Text)
+			y += textHeight / 2
+			points := []excalidraw.Point{{0, 0}, {lifeline(message.To) - lifeline(message.From), 0}}
+			if self {
+				points = loopPoints(rowHeight / 2)
+			}
+			arrow := arrowElement(newID(), lifeline(message.From), y, points, "", "", message.Text, "", message.Head)
+			if message.Dashed {
+				arrow.StrokeStyle = "dashed"
+			}
+			rows = append(rows, arrow)
+			if self {
+				y += rowHeight / 2
+			}
+			y += rowHeight
+			continue
+		}
+
+		note := step.Note
+		w, h := textSize(note.Text)
+		w, h = math.Max(nodeMinWidth, w+nodePadding), h+nodePadding/2
+		first := lifeline(note.Participants[0])
+		var left float64
+		switch note.Placement {
+		case "left of":
+			left = first - w - nodeGap/2
+		case "right of":
+			left = first + nodeGap/2
+		default:
+			last := lifeline(note.Participants[len(note.Participants)-1])
+			lo, hi := math.Min(first, last), math.Max(first, last)
+			w = math.Max(w, hi-lo+nodePadding)
+			left = (lo+hi)/2 - w/2
+		}
+		rows = append(rows, excalidraw.Element{
+			ID:              newID(),
+			Type:            excalidraw.TypeRectangle,
+			X:               left,
+			Y:               y - rowHeight/4,
+			Width:           w,
+			Height:          h,
+			BackgroundColor: noteColor,
+			FillStyle:       "solid",
+			Label:           &excalidraw.Label{Text: note.Text},
+		})
+		y += h + rowHeight/2
+	}
+
+	var elements []excalidraw.Element
+	for i, participant := range d.Participants {
+		b := boxes[i]
+		cx, _ := b.center()
+		elements = append(elements, excalidraw.Element{
+			ID:          newID(),
+			Type:        excalidraw.TypeLine,
+			X:           cx,
+			Y:           b.h,
+			Height:      y - b.h,
+			StrokeStyle: "dashed",
+			Points:      []excalidraw.Point{{0, 0}, {0, y - b.h}},
+		})
+		element := excalidraw.Element{
+			ID:     newID(),
+			Type:   excalidraw.TypeRectangle,
+			X:      b.x,
+			Width:  b.w,
+			Height: b.h,
+			Label:  &excalidraw.Label{Text: participant.Label},
+		}
+		if participant.Actor {
+			element.Type = excalidraw.TypeEllipse
+		}
+		elements = append(elements, element)
+	}
+	return append(elements, rows...)
+}
+
+// loopPoints is the path of an arrow that leaves and re-enters the right side
+// of a shape.
+func loopPoints(height float64) []excalidraw.Point {
+	return []excalidraw.Point{{0, 0}, {selfLoopWidth, 0}, {selfLoopWidth, height}, {0, height}}
+}
+
+// arrowElement builds a skeleton arrow starting at (x, y). Empty startID or
+// endID leave that end unbound; empty heads draw a plain line end.
+func arrowElement(id string, x, y float64, points []excalidraw.Point, startID, endID, label, startHead, endHead string) excalidraw.Element {
+	minX, minY, maxX, maxY := 0.0, 0.0, 0.0, 0.0
+	for _, p := range points {
+		minX, minY = math.Min(minX, p[0]), math.Min(minY, p[1])
+		maxX, maxY = math.Max(maxX, p[0]), math.Max(maxY, p[1])
+	}
+	arrow := excalidraw.Element{
+		ID:     id,
+		Type:   excalidraw.TypeArrow,
+		X:      x,
+		Y:      y,
+		Width:  maxX - minX,
+		Height: maxY - minY,
+		Points: points,
+	}
+	if startID != "" {
+		arrow.Start = &excalidraw.ElementRef{ID: startID}
+	}
+	if endID != "" {
+		arrow.End = &excalidraw.ElementRef{ID: endID}
+	}
+	if label != "" {
+		arrow.Label = &excalidraw.Label{Text: label}
+	}
+	if startHead != "" {
+		arrow.StartArrowhead = &startHead
+	}
+	if endHead != "" {
+		arrow.EndArrowhead = &endHead
+	} else {
+		// Skeleton arrows get an end arrowhead unless it is explicitly null.
+		arrow.Extra = map[string]json.RawMessage{"endArrowhead": json.RawMessage("null")}
+	}
+	return arrow
+}
@@ -0,0 +1,177 @@
+// Package mermaid parses Mermaid flowcharts and sequence diagrams and lays
+// them out as Excalidraw skeleton elements.
+//
+// Only the structure of a diagram is understood: nodes, links, participants,
+// messages and notes. Styling statements, subgraph boundaries and sequence
+// blocks (loop, alt, ...) are accepted and ignored.
+package mermaid
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Kind is the diagram type declared on the first line.
+type Kind int
+
+const (
+	KindFlowchart Kind = iota
+	KindSequence
+)
+
+// Shape is the outline of a flowchart node.
+type Shape int
+
+const (
+	ShapeRectangle Shape = iota
+	ShapeRounded
+	ShapeCircle
+	ShapeDiamond
+)
+
+// Node is a flowchart node.
+type Node struct {
+	ID    string
+	Label string
+	Shape Shape
+}
+
+// Edge is a flowchart link. StartHead and EndHead are Excalidraw arrowhead
+// names, or empty for a plain line end.
+type Edge struct {
+	From, To           string
+	Label              string
+	Dashed, Thick      bool
+	StartHead, EndHead string
+}
+
+// Participant is a sequence diagram lifeline.
+type Participant struct {
+	ID    string
+	Label string
+	Actor bool
+}
+
+// Message is an arrow between two lifelines. Head is an Excalidraw arrowhead
+// name, or empty for an open line.
+type Message struct {
+	From, To string
+	Text     string
+	Dashed   bool
+	Head     string
+}
+
+// Note is a sequence diagram note. Placement is "left of", "right of" or
+// "over"; only "over" may span two participants.
+type Note struct {
+	Participants []string
+	Placement    string
+	Text         string
+}
+
+// Step is one row of a sequence diagram: either a message or a note.
+type Step struct {
+	Message *Message
+	Note    *Note
+}
+
+// Diagram is a parsed Mermaid diagram.
+type Diagram struct {
+	Kind Kind
+
+	// Flowchart. Direction is TB, BT, LR or RL.
+	Direction string
+	Nodes     []Node
+	Edges     []Edge
+
+	// Sequence diagram.
+	Participants []Participant
+	Steps        []Step
+}
+
+// SyntaxError reports the first line Parse could not understand.
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Line == 0 {
+		return "mermaid: " + e.Msg
+	}
+	return fmt.Sprintf("mermaid: line %d: %s", e.Line, e.Msg)
+}
+
+// Parse parses a flowchart (or graph) or a sequence diagram.
+func Parse(src string) (*Diagram, error) {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if comment := strings.Index(line, "%%"); comment >= 0 {
+			line = line[:comment]
+		}
+		lines[i] = strings.TrimSpace(line)
+	}
+	lines = skipFrontMatter(lines)
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "graph", "flowchart":
+			direction := "TB"
+			if len(fields) > 1 {
+				direction = strings.ToUpper(strings.TrimSuffix(fields[1], ";"))
+			}
+			switch direction {
+			case "TD":
+				direction = "TB"
+			case "TB", "BT", "LR", "RL":
+			default:
+				return nil, &SyntaxError{Line: i + 1, Msg: fmt.Sprintf("unknown direction %q", direction)}
+			}
+			return parseFlowchart(lines, i+1, direction)
+		case "sequenceDiagram":
+			return parseSequence(lines, i+1)
+		default:
+			return nil, &SyntaxError{Line: i + 1, Msg: fmt.Sprintf("unsupported diagram type %q", fields[0])}
+		}
+	}
+	return nil, &SyntaxError{Msg: "empty diagram"}
+}
+
+// skipFrontMatter blanks a leading "---" YAML block so line numbers stay
+// correct.
+func skipFrontMatter(lines []string) []string {
+	start := 0
+	for start < len(lines) && lines[start] == "" {
+		start++
+	}
+	if start == len(lines) || lines[start] != "---" {
+		return lines
+	}
+	for end := start + 1; end < len(lines); end++ {
+		if lines[end] == "---" {
+			for i := start; i <= end; i++ {
+				lines[i] = ""
+			}
+			break
+		}
+	}
+	return lines
+}
+
+// cleanLabel turns Mermaid label markup into plain text.
+func cleanLabel(label string) string {
+	label = strings.TrimSpace(label)
+	if len(label) >= 2 && label[0] == '"' && label[len(label)-1] == '"' {
+		label = label[1 : len(label)-1]
+	}
+	label = brTag.ReplaceAllString(label, "\n")
+	return html.UnescapeString(label)
+}
+
+var brTag = regexp.MustCompile(`(?i)<br\s*/?>`)
@@ -0,0 +1,177 @@
+package mermaid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"draw/pkg/excalidraw"
+)
+
+func TestParseFlowchart(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		direction string
+		nodes     []Node
+		edges     []Edge
+	}{
+		{
+			name: "chain with shapes and labels",
+			src: `flowchart LR
+				%% a comment
+				A[Start] -->|go| B{Ready?}
+				B -- yes --> C((Done))
+				B -.-> A`,
+			direction: "LR",
+			nodes: []Node{
+				{ID: "A", Label: "Start", Shape: ShapeRectangle},
+				{ID: "B", Label: "Ready?", Shape: ShapeDiamond},
+				{ID: "C", Label: "Done", Shape: ShapeCircle},
+			},
+			edges: []Edge{
+				{From: "A", To: "B", Label: "go", EndHead: "arrow"},
+				{From: "B", To: "C", Label: "yes", EndHead: "arrow"},
+				{From: "B", To: "A", Dashed: true, EndHead: "arrow"},
+			},
+		},
+		{
+			name: "groups, semicolons and quoted labels",
+			src: `graph TD
+				a & b --- c("Line one<br/>two; three"); c ==> d
+				subgraph s
+				style a fill:#f9f
+				end`,
+			direction: "TB",
+			nodes: []Node{
+				{ID: "a", Label: "a"},
+				{ID: "b", Label: "b"},
+				{ID: "c", Label: "Line one\ntwo; three", Shape: ShapeRounded},
+				{ID: "d", Label: "d"},
+			},
+			edges: []Edge{
+				{From: "a", To: "c"},
+				{From: "b", To: "c"},
+				{From: "c", To: "d", Thick: true, EndHead: "arrow"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse() = %v", err)
+			}
+			if d.Kind != KindFlowchart || d.Direction != tt.direction {
+				t.Errorf("kind, direction = %v, %q", d.Kind, d.Direction)
+			}
+			if !reflect.DeepEqual(d.Nodes, tt.nodes) {
+				t.Errorf("nodes = %+v, want %+v", d.Nodes, tt.nodes)
+			}
+			if !reflect.DeepEqual(d.Edges, tt.edges) {
+				t.Errorf("edges = %+v, want %+v", d.Edges, tt.edges)
+			}
+		})
+	}
+}
+
+func TestParseSequence(t *testing.T) {
+	d, err := Parse(`sequenceDiagram
+		participant A as Alice
+		actor B
+		A->>+B: Hello
+		loop every minute
+		B-->>-A: Hi
+		end
+		Note over A,B: done
+		A-xC: bye`)
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+	wantParticipants := []Participant{
+		{ID: "A", Label: "Alice"},
+		{ID: "B", Label: "B", Actor: true},
+		{ID: "C", Label: "C"},
+	}
+	if !reflect.DeepEqual(d.Participants, wantParticipants) {
+		t.Errorf("participants = %+v, want %+v", d.Participants, wantParticipants)
+	}
+	wantSteps := []Step{
+		{Message: &Message{From: "A", To: "B", Text: "Hello", Head: "triangle"}},
+		{Message: &Message{From: "B", To: "A", Text: "Hi", Dashed: true, Head: "triangle"}},
+		{Note: &Note{Participants: []string{"A", "B"}, Placement: "over", Text: "done"}},
+		{Message: &Message{From: "A", To: "C", Text: "bye", Head: "bar"}},
+	}
+	if !reflect.DeepEqual(d.Steps, wantSteps) {
+		t.Errorf("steps = %+v, want %+v", d.Steps, wantSteps)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		line int
+	}{
+		{name: "empty", src: "  \n%% nothing", line: 0},
+		{name: "unsupported diagram", src: "pie\n\"a\": 1", line: 1},
+		{name: "unterminated label", src: "flowchart TD\nA[oops --> B", line: 2},
+		{name: "missing link", src: "flowchart TD\nA B", line: 2},
+		{name: "bad message", src: "sequenceDiagram\nA => B", line: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			var serr *SyntaxError
+			if !errors.As(err, &serr) {
+				t.Fatalf("Parse() error = %v, want *SyntaxError", err)
+			}
+			if serr.Line != tt.line {
+				t.Errorf("line = %d, want %d", serr.Line, tt.line)
+			}
+		})
+	}
+}
+
+func TestElements(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "flowchart", src: "flowchart TD\nA --> B\nA --> C\nC --> A\nB --> B"},
+		{name: "sequence", src: "sequenceDiagram\nA->>B: hi\nB->>B: think\nNote right of B: ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse() = %v", err)
+			}
+			n := 0
+			elements := Elements(d, func() string { n++; return fmt.Sprint("el", n) })
+			if err := excalidraw.Validate(elements); err != nil {
+				t.Fatalf("Validate() = %v", err)
+			}
+			minX, minY, _, _, _ := excalidraw.Bounds(elements)
+			if minX != 0 || minY != 0 {
+				t.Errorf("top left = (%v, %v), want origin", minX, minY)
+			}
+
+			// Shapes must not overlap each other.
+			var shapes []excalidraw.Element
+			for _, element := range elements {
+				if element.Type.IsShape() {
+					shapes = append(shapes, element)
+				}
+			}
+			for i, a := range shapes {
+				for _, b := range shapes[i+1:] {
+					if a.X < b.X+b.Width && b.X < a.X+a.Width && a.Y < b.Y+b.Height && b.Y < a.Y+a.Height {
+						t.Errorf("%s overlaps %s", a.Label.Text, b.Label.Text)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package mermaid
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sequenceKeywords start statements that do not add a row.
+var sequenceKeywords = map[string]bool{
+	"loop":       true,
+	"alt":        true,
+	"else":       true,
+	"opt":        true,
+	"par":        true,
+	"and":        true,
+	"critical":   true,
+	"option":     true,
+	"break":      true,
+	"rect":       true,
+	"end":        true,
+	"box":        true,
+	"activate":   true,
+	"deactivate": true,
+	"autonumber": true,
+	"title":      true,
+	"create":     true,
+	"destroy":    true,
+	"link":       true,
+	"links":      true,
+}
+
+var (
+	participantLine = regexp.MustCompile(`^(participant|actor)\s+(.+?)(?:\s+as\s+(.+))?$`)
+	noteLine        = regexp.MustCompile(`(?i)^note\s+(left of|right of|over)\s+([^:]+?)\s*:(.*)$`)
+	messageLine     = regexp.MustCompile(`^([^\s:]+?)\s*(--?)(>>|>|x|\))\s*[+-]?\s*([^\s:]+)\s*(?::(.*))?$`)
+)
+
+func parseSequence(lines []string, first int) (*Diagram, error) {
+	d := &Diagram{Kind: KindSequence}
+	seen := map[string]bool{}
+	declare := func(id, label string, actor bool) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		if label == "" {
+			label = id
+		}
+		d.Participants = append(d.Participants, Participant{ID: id, Label: label, Actor: actor})
+	}
+
+	for i := first; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+		if sequenceKeywords[strings.Fields(line)[0]] {
+			continue
+		}
+		if m := participantLine.FindStringSubmatch(line); m != nil {
+			declare(m[2], cleanLabel(m[3]), m[1] == "actor")
+			continue
+		}
+		if m := noteLine.FindStringSubmatch(line); m != nil {
+			var ids []string
+			for _, id := range strings.Split(m[2], ",") {
+				id = strings.TrimSpace(id)
+				declare(id, "", false)
+				ids = append(ids, id)
+			}
+			placement := strings.ToLower(m[1])
+			if len(ids) > 2 || (len(ids) == 2 && placement != "over") {
+				return nil, &SyntaxError{Line: i + 1, Msg: "a note spans at most two participants and only with \"over\""}
+			}
+			d.Steps = append(d.Steps, Step{Note: &Note{
+				Participants: ids,
+				Placement:    placement,
+				Text:         cleanLabel(m[3]),
+			}})
+			continue
+		}
+		if m := messageLine.FindStringSubmatch(line); m != nil {
+			declare(m[1], "", false)
+			declare(m[4], "", false)
+			d.Steps = append(d.Steps, Step{Message: &Message{
+				From:   m[1],
+				To:     m[4],
+				Text:   cleanLabel(m[5]),
+				Dashed: m[2] == "--",
+				Head:   messageHead(m[3]),
+			}})
+			continue
+		}
+		return nil, &SyntaxError{Line: i + 1, Msg: fmt.Sprintf("unrecognised statement %q", line)}
+	}
+	return d, nil
+}
+
+func messageHead(marker string) string {
+	switch marker {
+	case ">>":
+		return "triangle"
+	case ")":
+		return "arrow"
+	case "x":
+		return "bar"
+	}
+	return ""
+}
@@ -189,3 +189,36 @@ func (c *Client) CleanupSession(ctx context.Context, sessionID string) error {
 
 	return nil
 }
+
+// AudioCallback receives synthesized PCM16 little-endian audio. Returning an
+// error stops the synthesis.
+type AudioCallback func(audioChunk []byte) error
+
+// Synthesize streams speech for text at sampleRate to onAudio and returns
+// once all of it has been delivered. Cancelling ctx stops it early.
+func (c *Client) Synthesize(ctx context.Context, sessionID string, text string, sampleRate int, onAudio AudioCallback) error {
+	stream, err := c.client.StreamSynthesize(ctx, &pb.SynthesizeRequest{
+		SessionId:  sessionID,
+		Text:       text,
+		SampleRate: int32(sampleRate),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start synthesize stream: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive audio: %w", err)
+		}
+		if !resp.Success {
+			return fmt.Errorf("synthesis failed: %s", resp.Error)
+		}
+		if err := onAudio(resp.AudioChunk); err != nil {
+			return err
+		}
+	}
+}
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	SpeechService_StreamTranscribe_FullMethodName = "/speech.SpeechService/StreamTranscribe"
 	SpeechService_CleanupSession_FullMethodName   = "/speech.SpeechService/CleanupSession"
+	SpeechService_StreamSynthesize_FullMethodName = "/speech.SpeechService/StreamSynthesize"
 )
 
 // SpeechServiceClient is the client API for SpeechService service.
@@ -29,6 +30,7 @@ const (
 type SpeechServiceClient interface {
 	StreamTranscribe(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TranscribeRequest, TranscribeResponse], error)
 	CleanupSession(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error)
+	StreamSynthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SynthesizeResponse], error)
 }
 
 type speechServiceClient struct {
@@ -62,12 +64,32 @@ func (c *speechServiceClient) CleanupSession(ctx context.Context, in *CleanupReq
 	return out, nil
 }
 
+func (c *speechServiceClient) StreamSynthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SynthesizeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SpeechService_ServiceDesc.Streams[1], SpeechService_StreamSynthesize_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SynthesizeRequest, SynthesizeResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SpeechService_StreamSynthesizeClient = grpc.ServerStreamingClient[SynthesizeResponse]
+
 // SpeechServiceServer is the server API for SpeechService service.
 // All implementations must embed UnimplementedSpeechServiceServer
 // for forward compatibility.
 type SpeechServiceServer interface {
 	StreamTranscribe(grpc.BidiStreamingServer[TranscribeRequest, TranscribeResponse]) error
 	CleanupSession(context.Context, *CleanupRequest) (*CleanupResponse, error)
+	StreamSynthesize(*SynthesizeRequest, grpc.ServerStreamingServer[SynthesizeResponse]) error
 	mustEmbedUnimplementedSpeechServiceServer()
 }
 
@@ -84,6 +106,9 @@ func (UnimplementedSpeechServiceServer) StreamTranscribe(grpc.BidiStreamingServe
 func (UnimplementedSpeechServiceServer) CleanupSession(context.Context, *CleanupRequest) (*CleanupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CleanupSession not implemented")
 }
+func (UnimplementedSpeechServiceServer) StreamSynthesize(*SynthesizeRequest, grpc.ServerStreamingServer[SynthesizeResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSynthesize not implemented")
+}
 func (UnimplementedSpeechServiceServer) mustEmbedUnimplementedSpeechServiceServer() {}
 func (UnimplementedSpeechServiceServer) testEmbeddedByValue()                       {}
 
@@ -130,6 +155,17 @@ func _SpeechService_CleanupSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SpeechService_StreamSynthesize_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SynthesizeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpeechServiceServer).StreamSynthesize(m, &grpc.GenericServerStream[SynthesizeRequest, SynthesizeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SpeechService_StreamSynthesizeServer = grpc.ServerStreamingServer[SynthesizeResponse]
+
 // SpeechService_ServiceDesc is the grpc.ServiceDesc for SpeechService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -149,6 +185,11 @@ var SpeechService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamSynthesize",
+			Handler:       _SpeechService_StreamSynthesize_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "speech.proto",
 }
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() = %v", err)
+	}
+
+	if err := s.Put(ctx, "boards/b1/files/f1", []byte("png"), "image/png"); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	r, err := s.Get(ctx, "boards/b1/files/f1")
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if string(data) != "png" {
+		t.Errorf("Get() = %q, want %q", data, "png")
+	}
+
+	if err := s.Delete(ctx, "boards/b1/files/f1"); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	if err := s.Delete(ctx, "boards/b1/files/f1"); err != nil {
+		t.Errorf("Delete() of a missing object = %v", err)
+	}
+	if _, err := s.Get(ctx, "boards/b1/files/f1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() = %v, want ErrNotFound", err)
+	}
+
+	for _, key := range []string{"", "../escape", "a/../../b"} {
+		if err := s.Put(ctx, key, nil, ""); err == nil {
+			t.Errorf("Put(%q) succeeded, want an error", key)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Package storage keeps opaque binary objects, such as the images placed on
+// boards, under string keys.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"draw/pkg/config"
+)
+
+// ErrNotFound is returned by Get when no object is stored under the key.
+var ErrNotFound = errors.New("object not found")
+
+type Storage interface {
+	// Put stores data under key, replacing any existing object.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get opens the object stored under key. The caller closes it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object under key. Deleting a missing object is not
+	// an error.
+	Delete(ctx context.Context, key string) error
+}
+
+type Provider string
+
+const (
+	ProviderLocal Provider = "local"
+	ProviderS3    Provider = "s3"
+)
+
+// New returns the storage backend selected by cfg.Provider.
+func New(cfg *config.StorageConfig, aws *config.AWSConfig) (Storage, error) {
+	switch Provider(cfg.Provider) {
+	case ProviderLocal:
+		return NewLocalStorage(cfg.LocalDir)
+	case ProviderS3:
+		return NewS3Storage(aws)
+	default:
+		return nil, fmt.Errorf("unknown storage provider: %s", cfg.Provider)
+	}
+}
@@ -1,4 +1,4 @@
-"""Configuration for the Speech Service (STT only)."""
+"""Configuration for the Speech Service (STT and TTS)."""
 
 import os
 from dataclasses import dataclass
@@ -19,6 +19,15 @@ class STTConfig:
     sample_rate: int = 16000  # Audio sample rate (Hz)
 
 
+@dataclass
+class TTSConfig:
+    """Text-to-Speech configuration."""
+    
+    model: str = "en_US-lessac-medium.onnx"  # Piper voice model path
+    sample_rate: int = 24000  # Default output sample rate (Hz)
+    chunk_ms: int = 100  # Audio per streamed response (milliseconds)
+
+
 @dataclass
 class ServerConfig:
     """gRPC server configuration."""
@@ -33,6 +42,7 @@ class AppConfig:
     """Application configuration."""
     
     stt: STTConfig
+    tts: TTSConfig
     server: ServerConfig
     
     @classmethod
@@ -47,6 +57,11 @@ class AppConfig:
                 min_speech_duration=float(os.getenv("STT_MIN_SPEECH_DURATION", "0.3")),
                 sample_rate=int(os.getenv("STT_SAMPLE_RATE", "16000")),
             ),
+            tts=TTSConfig(
+                model=os.getenv("TTS_MODEL", "en_US-lessac-medium.onnx"),
+                sample_rate=int(os.getenv("TTS_SAMPLE_RATE", "24000")),
+                chunk_ms=int(os.getenv("TTS_CHUNK_MS", "100")),
+            ),
             server=ServerConfig(
                 host=os.getenv("GRPC_HOST", "0.0.0.0"),
                 port=int(os.getenv("GRPC_PORT", "50051")),
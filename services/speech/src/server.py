@@ -1,4 +1,4 @@
-"""gRPC server implementation for Speech Service (STT only)."""
+"""gRPC server implementation for Speech Service (STT and TTS)."""
 
 import logging
 import signal
@@ -11,6 +11,7 @@ import grpc
 
 from .config import config
 from .session_manager import session_manager
+from .synthesizer import synthesizer
 
 try:
     from . import speech_pb2
@@ -136,6 +137,24 @@ class SpeechServicer:
         logger.info(f"Session cleanup {'successful' if success else 'failed'}: {session_id}")
         
         return speech_pb2.CleanupResponse(success=success)
+    
+    def StreamSynthesize(self, request, context):
+        session_id = request.session_id
+        text = request.text.strip()
+        
+        if not text:
+            yield speech_pb2.SynthesizeResponse(success=False, error="text is empty")
+            return
+        
+        try:
+            for chunk in synthesizer.synthesize(text, request.sample_rate):
+                if not context.is_active():
+                    logger.debug(f"StreamSynthesize cancelled for {session_id}")
+                    return
+                yield speech_pb2.SynthesizeResponse(audio_chunk=chunk, success=True)
+        except Exception as e:
+            logger.error(f"StreamSynthesize error for {session_id}: {e}", exc_info=True)
+            yield speech_pb2.SynthesizeResponse(success=False, error=str(e))
 
 
 def serve():
@@ -164,8 +183,9 @@ def serve():
     signal.signal(signal.SIGTERM, shutdown_handler)
     
     server.start()
-    logger.info(f"Speech Service (STT) started on {address}")
+    logger.info(f"Speech Service (STT/TTS) started on {address}")
     logger.info(f"STT Model: {config.stt.model}")
+    logger.info(f"TTS Model: {config.tts.model}")
     logger.info(f"VAD Sensitivity: {config.stt.silero_sensitivity}")
     logger.info(f"Silence Duration: {config.stt.post_speech_silence_duration}s")
     
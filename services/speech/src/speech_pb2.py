@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0cspeech.proto\x12\x06speech\"S\n\x11TranscribeRequest\x12\x12\n\nsession_id\x18\x01 \x01(\t\x12\x13\n\x0b\x61udio_chunk\x18\x02 \x01(\x0c\x12\x15\n\rend_of_stream\x18\x03 \x01(\x08\"K\n\x12TranscribeResponse\x12\x15\n\rtranscription\x18\x01 \x01(\t\x12\x0f\n\x07success\x18\x02 \x01(\x08\x12\r\n\x05\x65rror\x18\x03 \x01(\t\"$\n\x0e\x43leanupRequest\x12\x12\n\nsession_id\x18\x01 \x01(\t\"\"\n\x0f\x43leanupResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x32\xa1\x01\n\rSpeechService\x12M\n\x10StreamTranscribe\x12\x19.speech.TranscribeRequest\x1a\x1a.speech.TranscribeResponse(\x01\x30\x01\x12\x41\n\x0e\x43leanupSession\x12\x16.speech.CleanupRequest\x1a\x17.speech.CleanupResponseB\x14Z\x12\x64raw/pkg/speech/pbb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0cspeech.proto\x12\x06speech\"S\n\x11TranscribeRequest\x12\x12\n\nsession_id\x18\x01 \x01(\t\x12\x13\n\x0b\x61udio_chunk\x18\x02 \x01(\x0c\x12\x15\n\rend_of_stream\x18\x03 \x01(\x08\"K\n\x12TranscribeResponse\x12\x15\n\rtranscription\x18\x01 \x01(\t\x12\x0f\n\x07success\x18\x02 \x01(\x08\x12\r\n\x05\x65rror\x18\x03 \x01(\t\"$\n\x0e\x43leanupRequest\x12\x12\n\nsession_id\x18\x01 \x01(\t\"\"\n\x0f\x43leanupResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\"Y\n\x11SynthesizeRequest\x12\x12\n\nsession_id\x18\x01 \x01(\t\x12\x0c\n\x04text\x18\x02 \x01(\t\x12\x13\n\x0bsample_rate\x18\x03 \x01(\x05\x12\r\n\x05voice\x18\x04 \x01(\t\"I\n\x12SynthesizeResponse\x12\x13\n\x0b\x61udio_chunk\x18\x01 \x01(\x0c\x12\x0f\n\x07success\x18\x02 \x01(\x08\x12\r\n\x05\x65rror\x18\x03 \x01(\t2\xee\x01\n\rSpeechService\x12M\n\x10StreamTranscribe\x12\x19.speech.TranscribeRequest\x1a\x1a.speech.TranscribeResponse(\x01\x30\x01\x12\x41\n\x0e\x43leanupSession\x12\x16.speech.CleanupRequest\x1a\x17.speech.CleanupResponse\x12K\n\x10StreamSynthesize\x12\x19.speech.SynthesizeRequest\x1a\x1a.speech.SynthesizeResponse0\x01\x42\x14Z\x12\x64raw/pkg/speech/pbb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -40,6 +40,10 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_CLEANUPREQUEST']._serialized_end=222
   _globals['_CLEANUPRESPONSE']._serialized_start=224
   _globals['_CLEANUPRESPONSE']._serialized_end=258
-  _globals['_SPEECHSERVICE']._serialized_start=261
-  _globals['_SPEECHSERVICE']._serialized_end=422
+  _globals['_SYNTHESIZEREQUEST']._serialized_start=260
+  _globals['_SYNTHESIZEREQUEST']._serialized_end=349
+  _globals['_SYNTHESIZERESPONSE']._serialized_start=351
+  _globals['_SYNTHESIZERESPONSE']._serialized_end=424
+  _globals['_SPEECHSERVICE']._serialized_start=427
+  _globals['_SPEECHSERVICE']._serialized_end=665
 # @@protoc_insertion_point(module_scope)
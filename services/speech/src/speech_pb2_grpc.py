@@ -44,6 +44,11 @@ class SpeechServiceStub(object):
                 request_serializer=speech__pb2.CleanupRequest.SerializeToString,
                 response_deserializer=speech__pb2.CleanupResponse.FromString,
                 _registered_method=True)
+        self.StreamSynthesize = channel.unary_stream(
+                '/speech.SpeechService/StreamSynthesize',
+                request_serializer=speech__pb2.SynthesizeRequest.SerializeToString,
+                response_deserializer=speech__pb2.SynthesizeResponse.FromString,
+                _registered_method=True)
 
 
 class SpeechServiceServicer(object):
@@ -61,6 +66,12 @@ class SpeechServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def StreamSynthesize(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_SpeechServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -74,6 +85,11 @@ def add_SpeechServiceServicer_to_server(servicer, server):
                     request_deserializer=speech__pb2.CleanupRequest.FromString,
                     response_serializer=speech__pb2.CleanupResponse.SerializeToString,
             ),
+            'StreamSynthesize': grpc.unary_stream_rpc_method_handler(
+                    servicer.StreamSynthesize,
+                    request_deserializer=speech__pb2.SynthesizeRequest.FromString,
+                    response_serializer=speech__pb2.SynthesizeResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'speech.SpeechService', rpc_method_handlers)
@@ -138,3 +154,30 @@ class SpeechService(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def StreamSynthesize(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_stream(
+            request,
+            target,
+            '/speech.SpeechService/StreamSynthesize',
+            speech__pb2.SynthesizeRequest.SerializeToString,
+            speech__pb2.SynthesizeResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -0,0 +1,60 @@
+"""Text-to-Speech synthesis with Piper."""
+
+import logging
+import threading
+from typing import Iterator
+
+import numpy as np
+
+from .config import config, TTSConfig
+
+logger = logging.getLogger(__name__)
+
+
+class Synthesizer:
+    """Turns text into 16-bit mono PCM. The voice model is loaded on first use
+    and shared by every request."""
+
+    def __init__(self, tts_config: TTSConfig):
+        self.tts_config = tts_config
+        self._voice = None
+        self._lock = threading.Lock()
+
+    def _get_voice(self):
+        with self._lock:
+            if self._voice is None:
+                from piper.voice import PiperVoice
+                self._voice = PiperVoice.load(self.tts_config.model)
+                logger.info(
+                    f"Loaded Piper voice {self.tts_config.model} "
+                    f"({self._voice.config.sample_rate} Hz)"
+                )
+            return self._voice
+
+    def synthesize(self, text: str, sample_rate: int = 0) -> Iterator[bytes]:
+        """Yield PCM16 little-endian audio for text at sample_rate, in chunks
+        of about chunk_ms. Audio is produced a sentence at a time, so playback
+        can start before the whole text is synthesized."""
+        sample_rate = sample_rate or self.tts_config.sample_rate
+        voice = self._get_voice()
+        chunk_bytes = sample_rate * self.tts_config.chunk_ms // 1000 * 2
+
+        for sentence in voice.synthesize_stream_raw(text):
+            audio = _resample(sentence, voice.config.sample_rate, sample_rate)
+            for start in range(0, len(audio), chunk_bytes):
+                yield audio[start:start + chunk_bytes]
+
+
+def _resample(audio: bytes, from_rate: int, to_rate: int) -> bytes:
+    """Linearly resample PCM16 mono audio."""
+    if from_rate == to_rate or not audio:
+        return audio
+    samples = np.frombuffer(audio, dtype=np.int16).astype(np.float32)
+    duration = len(samples) / from_rate
+    target = np.linspace(0, len(samples) - 1, int(duration * to_rate))
+    resampled = np.interp(target, np.arange(len(samples)), samples)
+    return resampled.astype(np.int16).tobytes()
+
+
+# Global synthesizer instance
+synthesizer = Synthesizer(config.tts)